@@ -3,6 +3,7 @@ package ast
 import (
 	"bytes"
 	"language/token"
+	"math/big"
 	"strings"
 )
 
@@ -38,7 +39,10 @@ func (cs *ConstStatement) String() string {
 	var out bytes.Buffer
 
 	out.WriteString(cs.TokenLiteral() + " ")
-	out.WriteString(cs.Type.Literal + " ")
+	//	the type is left out when it was not provided
+	if cs.Type.Literal != "" {
+		out.WriteString(cs.Type.Literal + " ")
+	}
 	out.WriteString(cs.Name.String())
 	out.WriteString(" = ")
 
@@ -64,7 +68,10 @@ func (vs *VarStatement) String() string {
 	var out bytes.Buffer
 
 	out.WriteString(vs.TokenLiteral() + " ")
-	out.WriteString(vs.Type.Literal + " ")
+	//	the type is left out when it was not provided
+	if vs.Type.Literal != "" {
+		out.WriteString(vs.Type.Literal + " ")
+	}
 	out.WriteString(vs.Name.String())
 	out.WriteString(" = ")
 
@@ -125,12 +132,22 @@ func (i *Identifier) String() string { return i.Value }
 type IntegerLiteral struct {
 	Token token.Token
 	Value int64
+	Big *big.Int //	the value of literals too big for an int64, nil for the others
 }
 
 func (il *IntegerLiteral) expressionNode() {}
 func (il *IntegerLiteral) TokenLiteral() string { return il.Token.Literal }
 func (il *IntegerLiteral) String() string { return il.Token.Literal }
 
+type DoubleLiteral struct {
+	Token token.Token
+	Value float64
+}
+
+func (dl *DoubleLiteral) expressionNode() {}
+func (dl *DoubleLiteral) TokenLiteral() string { return dl.Token.Literal }
+func (dl *DoubleLiteral) String() string { return dl.Token.Literal }
+
 type PrefixExpression struct {
 	Token token.Token
 	Operator string
@@ -180,6 +197,23 @@ func (b *Boolean) expressionNode() {}
 func (b *Boolean) TokenLiteral() string { return b.Token.Literal }
 func (b *Boolean) String() string { return b.Token.Literal }
 
+type PropagateExpression struct {
+	Token token.Token //	the ? token
+	Left Expression
+}
+
+func (pe *PropagateExpression) expressionNode() {}
+func (pe *PropagateExpression) TokenLiteral() string { return pe.Token.Literal }
+func (pe *PropagateExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(pe.Left.String())
+	out.WriteString("?)")
+
+	return out.String()
+}
+
 type IfExpression struct {
 	Token token.Token //	if token
 	Condition Expression
@@ -324,9 +358,83 @@ func (ie *IndexExpression) String() string {
 	return out.String()
 }
 
+//	SliceExpression is an index expression with a range, like arr[1:3].
+//	Start and End are nil when they are left out, like in arr[:2] or arr[2:]
+type SliceExpression struct {
+	Token token.Token //	the [ token
+	Left Expression
+	Start Expression
+	End Expression
+}
+
+func (se *SliceExpression) expressionNode() {}
+func (se *SliceExpression) TokenLiteral() string { return se.Token.Literal }
+func (se *SliceExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(se.Left.String())
+	out.WriteString("[")
+	if se.Start != nil {
+		out.WriteString(se.Start.String())
+	}
+	out.WriteString(":")
+	if se.End != nil {
+		out.WriteString(se.End.String())
+	}
+	out.WriteString("])")
+
+	return out.String()
+}
+
+//	MemberExpression reads a name from the value on its left, like utils.helper
+type MemberExpression struct {
+	Token token.Token //	the . token
+	Left Expression
+	Property *Identifier
+}
+
+func (me *MemberExpression) expressionNode() {}
+func (me *MemberExpression) TokenLiteral() string { return me.Token.Literal }
+func (me *MemberExpression) String() string {
+	return me.Left.String() + "." + me.Property.String()
+}
+
+//	FieldValue is the value given to a field when making a record, like x: 1
+type FieldValue struct {
+	Name *Identifier
+	Value Expression
+}
+
+//	StructLiteral makes a record of a struct, like Point{x: 1, y: 2}
+type StructLiteral struct {
+	Token token.Token //	the { token
+	Struct Expression //	the struct, named directly or read from a module
+	Fields []*FieldValue //	fields in the order they were written
+}
+
+func (sl *StructLiteral) expressionNode() {}
+func (sl *StructLiteral) TokenLiteral() string { return sl.Token.Literal }
+func (sl *StructLiteral) String() string {
+	var out bytes.Buffer
+
+	fields := []string{}
+	for _, field := range sl.Fields {
+		fields = append(fields, field.Name.String() + ": " + field.Value.String())
+	}
+
+	out.WriteString(sl.Struct.String())
+	out.WriteString("{")
+	out.WriteString(strings.Join(fields, ", "))
+	out.WriteString("}")
+
+	return out.String()
+}
+
 type MapLiteral struct {
 	Token token.Token //	the { token
 	Pairs map[Expression]Expression
+	Keys []Expression //	keys in the order they were written
 }
 
 func (ml *MapLiteral) expressionNode() {}
@@ -335,8 +443,8 @@ func (ml *MapLiteral) String() string {
 	var out bytes.Buffer
 
 	pairs := []string{}
-	for key, value := range ml.Pairs {
-		pairs = append(pairs, key.String() + ":" + value.String())
+	for _, key := range ml.Keys {
+		pairs = append(pairs, key.String() + ":" + ml.Pairs[key].String())
 	}
 
 	out.WriteString("{")
@@ -383,6 +491,116 @@ func (rs *ReassignStatement) String() string {
 	return out.String()
 }
 
+type ThrowStatement struct {
+	Token token.Token //	the 'throw' token
+	Value Expression
+}
+
+func (ts *ThrowStatement) statementNode() {}
+func (ts *ThrowStatement) TokenLiteral() string { return ts.Token.Literal }
+func (ts *ThrowStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(ts.TokenLiteral() + " ")
+	out.WriteString(ts.Value.String())
+	out.WriteString(";")
+
+	return out.String()
+}
+
+type TryStatement struct {
+	Token token.Token //	the 'try' token
+	Block *BlockStatement
+	Parameter *Identifier //	name given to the caught error
+	Catch *BlockStatement //	nil if there is no catch
+	Finally *BlockStatement //	nil if there is no finally
+}
+
+func (ts *TryStatement) statementNode() {}
+func (ts *TryStatement) TokenLiteral() string { return ts.Token.Literal }
+func (ts *TryStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("try {")
+	out.WriteString(ts.Block.String())
+	out.WriteString("}")
+
+	if ts.Catch != nil {
+		out.WriteString(" catch (" + ts.Parameter.String() + ") {")
+		out.WriteString(ts.Catch.String())
+		out.WriteString("}")
+	}
+
+	if ts.Finally != nil {
+		out.WriteString(" finally {")
+		out.WriteString(ts.Finally.String())
+		out.WriteString("}")
+	}
+
+	return out.String()
+}
+
+//	ImportStatement loads another file as a module and names it, like import "utils.smp" as utils;
+type ImportStatement struct {
+	Token token.Token //	the 'import' token
+	Path *StringLiteral
+	Name *Identifier
+}
+
+func (is *ImportStatement) statementNode() {}
+func (is *ImportStatement) TokenLiteral() string { return is.Token.Literal }
+func (is *ImportStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(is.TokenLiteral() + " ")
+	out.WriteString(`"` + is.Path.Value + `"`)
+	out.WriteString(" as " + is.Name.String())
+	out.WriteString(";")
+
+	return out.String()
+}
+
+//	ExportStatement is a var, const or struct statement that the files importing this one can use
+type ExportStatement struct {
+	Token token.Token //	the 'export' token
+	Statement Statement
+	Name *Identifier //	name of the variable or struct being exported
+}
+
+func (es *ExportStatement) statementNode() {}
+func (es *ExportStatement) TokenLiteral() string { return es.Token.Literal }
+func (es *ExportStatement) String() string {
+	return es.TokenLiteral() + " " + es.Statement.String()
+}
+
+//	StructField is a field declared in a struct, with its type
+type StructField struct {
+	Type token.Token //	a data type or the name of another struct
+	Name *Identifier
+}
+
+//	StructStatement declares a struct, like struct Point { int x; int y; }
+type StructStatement struct {
+	Token token.Token //	the 'struct' token
+	Name *Identifier
+	Fields []*StructField
+}
+
+func (ss *StructStatement) statementNode() {}
+func (ss *StructStatement) TokenLiteral() string { return ss.Token.Literal }
+func (ss *StructStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(ss.TokenLiteral() + " ")
+	out.WriteString(ss.Name.String() + " {")
+	for _, field := range ss.Fields {
+		out.WriteString(" " + field.Type.Literal + " " + field.Name.String() + ";")
+	}
+	out.WriteString(" }")
+
+	return out.String()
+}
+
 func (p *Program) TokenLiteral() string {
 	if len(p.Statements) > 0 {
 		return p.Statements[0].TokenLiteral()
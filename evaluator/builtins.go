@@ -3,11 +3,14 @@ package evaluator
 import (
 	"fmt"
 	"language/object"
+	"sort"
+	"strings"
+	"unicode/utf8"
 )
 
-var builtins = map[string]*object.BuiltIn{
+var builtins = map[string]*builtin{
 	"length": {
-		Fn: func(args ...object.Object) object.Object {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
 			if len(args) != 1 {
 				return newError("Wrong number of arguments, expected 1, got %d", len(args))
 			}
@@ -16,14 +19,17 @@ var builtins = map[string]*object.BuiltIn{
 			case *object.Array:
 				return &object.Integer{ Value: int64(len(arg.Elements)) }
 			case *object.String:
-				return &object.Integer{ Value: int64(len(arg.Value)) }
+				//	strings are measured in characters, the same way they are indexed
+				return &object.Integer{ Value: int64(utf8.RuneCountInString(arg.Value)) }
+			case *object.Map:
+				return &object.Integer{ Value: int64(arg.Len()) }
 			default:
 				return newError("Argument to `length` not supported, got %s", args[0].Type())
 			}
 		},
 	},
 	"firstElement": {
-		Fn: func(args ...object.Object) object.Object {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
 			if len(args) != 1 {
 				return newError("Wrong number of arguments. Expected 1, got %d", len(args))
 			}
@@ -41,7 +47,7 @@ var builtins = map[string]*object.BuiltIn{
 		},
 	},
 	"lastElement": {
-		Fn: func(args ...object.Object) object.Object {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
 			if len(args) != 1 {
 				return newError("Wrong number of arguments. Got %d, expected 1", len(args))
 			}
@@ -59,7 +65,7 @@ var builtins = map[string]*object.BuiltIn{
 		},
 	},
 	"push": {
-		Fn: func(args ...object.Object) object.Object {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
 			if len(args) != 2 {
 				return newError("Wrong number of arguments. Got %d, expected 2", len(args))
 			}
@@ -86,7 +92,7 @@ var builtins = map[string]*object.BuiltIn{
 		},
 	},
 	"removeLast": {
-		Fn: func(args ...object.Object) object.Object {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
 			if len(args) != 1 {
 				return newError("Wrong number of arguments. Got %d, expected 1", len(args))
 			}
@@ -110,7 +116,7 @@ var builtins = map[string]*object.BuiltIn{
 		},
 	},
 	"removeAt": {
-		Fn: func(args ...object.Object) object.Object {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
 			if len(args) != 2 {
 				return newError("Wrong number of arguments. Got %d, expected 2", len(args))
 			}
@@ -154,7 +160,7 @@ var builtins = map[string]*object.BuiltIn{
 		},
 	},
 	"copy": {
-		Fn: func(args ...object.Object) object.Object {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
 			if len(args) != 1 {
 				return newError("Wrong number of arguments. Got %d, expected 1", len(args))
 			}
@@ -171,17 +177,453 @@ var builtins = map[string]*object.BuiltIn{
 			return &object.Array{ Elements: arr.Elements }
 		},
 	},
+	"error": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 1 && len(args) != 2 {
+				return newError("Wrong number of arguments. Expected 1 or 2, got %d", len(args))
+			}
+
+			message, ok := args[0].(*object.String)
+			if !ok {
+				return newError("First argument to `error` must be a String, got %s", args[0].Type())
+			}
+			//	the kind of the error is optional
+			kind := THROWN_ERROR
+			if len(args) == 2 {
+				kindArg, ok := args[1].(*object.String)
+				if !ok {
+					return newError("Second argument to `error` must be a String, got %s", args[1].Type())
+				}
+				kind = kindArg.Value
+			}
+
+			return newErrorMap(message.Value, kind, []object.Frame{})
+		},
+	},
+	"isError": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("Wrong number of arguments. Expected 1, got %d", len(args))
+			}
+
+			return nativeBoolToBooleaObject(isErrorMap(args[0]))
+		},
+	},
+	"errorMessage": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("Wrong number of arguments. Expected 1, got %d", len(args))
+			}
+
+			if !isErrorMap(args[0]) {
+				return newError("Argument to `errorMessage` must be an error, got %s", args[0].Type())
+			}
+
+			message, _ := getMapValue(args[0].(*object.Map), "message")
+			return message
+		},
+	},
+	"keys": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("Wrong number of arguments. Got %d, expected 1", len(args))
+			}
+
+			mapObject, ok := args[0].(*object.Map)
+			if !ok {
+				return newError("Argument to `keys` must be a Map, got %s", args[0].Type())
+			}
+			//	the keys are returned in insertion order
+			keys := []object.Object{}
+			for _, pair := range mapObject.Pairs() {
+				keys = append(keys, pair.Key)
+			}
+
+			return &object.Array{ Elements: keys }
+		},
+	},
+	"values": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("Wrong number of arguments. Got %d, expected 1", len(args))
+			}
+
+			mapObject, ok := args[0].(*object.Map)
+			if !ok {
+				return newError("Argument to `values` must be a Map, got %s", args[0].Type())
+			}
+
+			values := []object.Object{}
+			for _, pair := range mapObject.Pairs() {
+				values = append(values, pair.Value)
+			}
+
+			return &object.Array{ Elements: values }
+		},
+	},
+	"entries": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("Wrong number of arguments. Got %d, expected 1", len(args))
+			}
+
+			mapObject, ok := args[0].(*object.Map)
+			if !ok {
+				return newError("Argument to `entries` must be a Map, got %s", args[0].Type())
+			}
+			//	each entry is an array with the key and the value
+			entries := []object.Object{}
+			for _, pair := range mapObject.Pairs() {
+				entries = append(entries, &object.Array{ Elements: []object.Object{ pair.Key, pair.Value } })
+			}
+
+			return &object.Array{ Elements: entries }
+		},
+	},
+	"has": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 2 {
+				return newError("Wrong number of arguments. Got %d, expected 2", len(args))
+			}
+
+			mapObject, ok := args[0].(*object.Map)
+			if !ok {
+				return newError("First argument to `has` must be a Map, got %s", args[0].Type())
+			}
+
+			if !object.Hashable(args[1]) {
+				return newError("Unsupported as map key: %s", args[1].Type())
+			}
+
+			_, found := mapObject.Get(args[1])
+			return nativeBoolToBooleaObject(found)
+		},
+	},
+	"delete": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 2 {
+				return newError("Wrong number of arguments. Got %d, expected 2", len(args))
+			}
+
+			mapObject, ok := args[0].(*object.Map)
+			if !ok {
+				return newError("First argument to `delete` must be a Map, got %s", args[0].Type())
+			}
+
+			if !object.Hashable(args[1]) {
+				return newError("Unsupported as map key: %s", args[1].Type())
+			}
+			//	deletes the key from the map passed and returns the same map
+			mapObject.Delete(args[1])
+			return mapObject
+		},
+	},
+	"set": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 3 {
+				return newError("Wrong number of arguments. Got %d, expected 3", len(args))
+			}
+
+			mapObject, ok := args[0].(*object.Map)
+			if !ok {
+				return newError("First argument to `set` must be a Map, got %s", args[0].Type())
+			}
+
+			if !object.Hashable(args[1]) {
+				return newError("Unsupported as map key: %s", args[1].Type())
+			}
+			//	sets the value on the map passed and returns the same map
+			mapObject.Set(args[1], args[2])
+			return mapObject
+		},
+	},
+	"merge": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 2 {
+				return newError("Wrong number of arguments. Got %d, expected 2", len(args))
+			}
+
+			first, ok := args[0].(*object.Map)
+			if !ok {
+				return newError("First argument to `merge` must be a Map, got %s", args[0].Type())
+			}
+
+			second, ok := args[1].(*object.Map)
+			if !ok {
+				return newError("Second argument to `merge` must be a Map, got %s", args[1].Type())
+			}
+			//	makes a new map with the pairs of both, the second one winning on repeated keys
+			merged := object.NewMap()
+			for _, pair := range first.Pairs() {
+				merged.Set(pair.Key, pair.Value)
+			}
+			for _, pair := range second.Pairs() {
+				merged.Set(pair.Key, pair.Value)
+			}
+
+			return merged
+		},
+	},
+	"map": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			array, fn, err := arrayAndFunction("map", args)
+			if err != nil {
+				return err
+			}
+			//	makes a new array with the result of calling the function on each element
+			mapped := make([]object.Object, len(array.Elements))
+			for i, element := range array.Elements {
+				result := in.callFunction(fn, element)
+				if isReturnOrError(result) {
+					return result
+				}
+
+				mapped[i] = result
+			}
+
+			return &object.Array{ Elements: mapped }
+		},
+	},
+	"filter": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			array, fn, err := arrayAndFunction("filter", args)
+			if err != nil {
+				return err
+			}
+			//	keeps the elements the function returns a truthy value for
+			filtered := []object.Object{}
+			for _, element := range array.Elements {
+				result := in.callFunction(fn, element)
+				if isReturnOrError(result) {
+					return result
+				}
+
+				if isTruthy(result) {
+					filtered = append(filtered, element)
+				}
+			}
+
+			return &object.Array{ Elements: filtered }
+		},
+	},
+	"reduce": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 3 {
+				return newError("Wrong number of arguments. Got %d, expected 3", len(args))
+			}
+
+			array, fn, err := arrayAndFunction("reduce", args[:2])
+			if err != nil {
+				return err
+			}
+			//	the function receives the accumulated value and the element, starting with the third argument
+			accumulated := args[2]
+			for _, element := range array.Elements {
+				accumulated = in.callFunction(fn, accumulated, element)
+				if isReturnOrError(accumulated) {
+					return accumulated
+				}
+			}
+
+			return accumulated
+		},
+	},
+	"forEach": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			array, fn, err := arrayAndFunction("forEach", args)
+			if err != nil {
+				return err
+			}
+
+			for _, element := range array.Elements {
+				result := in.callFunction(fn, element)
+				if isReturnOrError(result) {
+					return result
+				}
+			}
+
+			return NULL
+		},
+	},
+	"find": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			array, fn, err := arrayAndFunction("find", args)
+			if err != nil {
+				return err
+			}
+
+			idx, result := findElement(in, array, fn)
+			if result != nil {
+				return result
+			}
+			//	if no element matches, returns null
+			if idx < 0 {
+				return NULL
+			}
+
+			return array.Elements[idx]
+		},
+	},
+	"findIndex": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			array, fn, err := arrayAndFunction("findIndex", args)
+			if err != nil {
+				return err
+			}
+
+			idx, result := findElement(in, array, fn)
+			if result != nil {
+				return result
+			}
+			//	if no element matches, returns -1
+			return &object.Integer{ Value: int64(idx) }
+		},
+	},
+	"any": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			array, fn, err := arrayAndFunction("any", args)
+			if err != nil {
+				return err
+			}
+
+			idx, result := findElement(in, array, fn)
+			if result != nil {
+				return result
+			}
+
+			return nativeBoolToBooleaObject(idx >= 0)
+		},
+	},
+	"all": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			array, fn, err := arrayAndFunction("all", args)
+			if err != nil {
+				return err
+			}
+			//	stops at the first element the function returns a falsy value for
+			for _, element := range array.Elements {
+				result := in.callFunction(fn, element)
+				if isReturnOrError(result) {
+					return result
+				}
+
+				if !isTruthy(result) {
+					return FALSE
+				}
+			}
+
+			return TRUE
+		},
+	},
+	"sort": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 1 && len(args) != 2 {
+				return newError("Wrong number of arguments. Expected 1 or 2, got %d", len(args))
+			}
+
+			array, ok := args[0].(*object.Array)
+			if !ok {
+				return newError("First argument to `sort` must be an Array, got %s", args[0].Type())
+			}
+			//	sorts a copy, leaving the array passed untouched
+			sorted := make([]object.Object, len(array.Elements))
+			copy(sorted, array.Elements)
+
+			if len(args) == 1 {
+				sort.SliceStable(sorted, func(i, j int) bool {
+					return compareObjects(sorted[i], sorted[j]) < 0
+				})
+
+				return &object.Array{ Elements: sorted }
+			}
+
+			if !isFunction(args[1]) {
+				return newError("Second argument to `sort` must be a Function, got %s", args[1].Type())
+			}
+			//	the comparison function returns a negative integer when its first argument goes first,
+			//	the sort stops comparing once it fails
+			var failure object.Object
+			sort.SliceStable(sorted, func(i, j int) bool {
+				if failure != nil {
+					return false
+				}
+
+				result := in.callFunction(args[1], sorted[i], sorted[j])
+				if isReturnOrError(result) {
+					failure = result
+					return false
+				}
+
+				order, ok := result.(*object.Integer)
+				if !ok {
+					failure = newError("Comparison function passed to `sort` must return an Integer, got %s", result.Type())
+					return false
+				}
+
+				return order.Value < 0
+			})
+
+			if failure != nil {
+				return failure
+			}
+
+			return &object.Array{ Elements: sorted }
+		},
+	},
 	"print": {
-		Fn: func(args ...object.Object) object.Object {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			for _, arg := range args {
+				fmt.Fprintln(in.Out, arg.Inspect())
+			}
+
+			return NULL
+		},
+	},
+	"println": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			//	prints every argument in the same line, separated by spaces
+			values := make([]string, len(args))
+			for i, arg := range args {
+				values[i] = arg.Inspect()
+			}
+
+			fmt.Fprintln(in.Out, strings.Join(values, " "))
+
+			return NULL
+		},
+	},
+	"printf": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) == 0 {
+				return newError("Wrong number of arguments. Expected at least 1, got 0")
+			}
+
+			format, ok := args[0].(*object.String)
+			if !ok {
+				return newError("First argument to `printf` must be a String, got %s", args[0].Type())
+			}
+
+			values := make([]interface{}, len(args) - 1)
+			for i, arg := range args[1:] {
+				values[i] = formatValue(arg)
+			}
+			//	no line break is added, the format has to include it
+			fmt.Fprintf(in.Out, format.Value, values...)
+
+			return NULL
+		},
+	},
+	"eprint": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
 			for _, arg := range args {
-				fmt.Println(arg.Inspect())
+				fmt.Fprintln(in.ErrOut, arg.Inspect())
 			}
 
 			return NULL
 		},
 	},
 	"range": {
-		Fn: func(args ...object.Object) object.Object {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
 			//	check that the correct number of arguments is received
 			if len(args) > 2 || len(args) == 0 {
 				return newError("Wrong number of arguments. Expected 1 or 2, got %d", len(args))
@@ -237,3 +679,74 @@ var builtins = map[string]*object.BuiltIn{
 		},
 	},
 }
+
+func isFunction(obj object.Object) bool {
+	switch obj.(type) {
+	case *object.Function, *object.BuiltIn:
+		return true
+	}
+
+	return false
+}
+
+//	arrayAndFunction checks the arguments of the builtins that call a function on each element of an array
+func arrayAndFunction(name string, args []object.Object) (*object.Array, object.Object, *object.Error) {
+	if len(args) != 2 {
+		return nil, nil, newError("Wrong number of arguments. Got %d, expected 2", len(args))
+	}
+
+	array, ok := args[0].(*object.Array)
+	if !ok {
+		return nil, nil, newError("First argument to `%s` must be an Array, got %s", name, args[0].Type())
+	}
+
+	if !isFunction(args[1]) {
+		return nil, nil, newError("Second argument to `%s` must be a Function, got %s", name, args[1].Type())
+	}
+
+	return array, args[1], nil
+}
+
+//	findElement returns the index of the first element the function returns a truthy value for,
+//	or -1 if there is none. If the function fails, the error is returned too
+func findElement(in *Interpreter, array *object.Array, fn object.Object) (int, object.Object) {
+	for i, element := range array.Elements {
+		result := in.callFunction(fn, element)
+		if isReturnOrError(result) {
+			return -1, result
+		}
+
+		if isTruthy(result) {
+			return i, nil
+		}
+	}
+
+	return -1, nil
+}
+
+//	compareObjects gives the default order of sort: numbers by value, strings alphabetically,
+//	times from the earliest and false before true. Values of different types are ordered by their type name
+func compareObjects(left, right object.Object) int {
+	if isNumber(left) && isNumber(right) {
+		return compareNumbers(left, right)
+	}
+
+	if left.Type() != right.Type() {
+		return strings.Compare(string(left.Type()), string(right.Type()))
+	}
+
+	switch left := left.(type) {
+	case *object.String:
+		return strings.Compare(left.Value, right.(*object.String).Value)
+	case *object.Time:
+		return left.Value.Compare(right.(*object.Time).Value)
+	case *object.Boolean:
+		if !left.Value && right.(*object.Boolean).Value {
+			return -1
+		} else if left.Value && !right.(*object.Boolean).Value {
+			return 1
+		}
+	}
+	//	any other values keep the order they had
+	return 0
+}
@@ -0,0 +1,187 @@
+package evaluator
+
+import (
+	"language/object"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+//	oneArgument checks that the builtin received a single argument and returns it
+func oneArgument(args []object.Object) (object.Object, *object.Error) {
+	if len(args) != 1 {
+		return nil, newError("Wrong number of arguments. Got %d, expected 1", len(args))
+	}
+
+	return args[0], nil
+}
+
+func toInteger(arg object.Object) object.Object {
+	switch arg := arg.(type) {
+	case *object.Integer, *object.BigInt:
+		return arg
+	case *object.Double:
+		if math.IsNaN(arg.Value) || math.IsInf(arg.Value, 0) {
+			return newError("Cannot convert %s to an integer", arg.Inspect())
+		}
+		//	the decimals are dropped, and doubles too big for an Integer become big integers
+		value, _ := big.NewFloat(math.Trunc(arg.Value)).Int(nil)
+		return normalizeBigInt(value)
+	case *object.String:
+		value, ok := new(big.Int).SetString(strings.TrimSpace(arg.Value), 10)
+		if !ok {
+			return newError("Cannot convert %q to an integer", arg.Value)
+		}
+
+		return normalizeBigInt(value)
+	case *object.Boolean:
+		if arg.Value {
+			return &object.Integer{ Value: 1 }
+		}
+
+		return &object.Integer{ Value: 0 }
+	default:
+		return newError("Cannot convert %s to an integer", arg.Type())
+	}
+}
+
+func toDouble(arg object.Object) object.Object {
+	switch arg := arg.(type) {
+	case *object.Double:
+		return arg
+	case *object.Integer:
+		return &object.Double{ Value: float64(arg.Value) }
+	case *object.BigInt:
+		value, _ := new(big.Float).SetInt(arg.Value).Float64()
+		return &object.Double{ Value: value }
+	case *object.String:
+		value, err := strconv.ParseFloat(strings.TrimSpace(arg.Value), 64)
+		if err != nil {
+			return newError("Cannot convert %q to a double", arg.Value)
+		}
+
+		return &object.Double{ Value: value }
+	case *object.Boolean:
+		if arg.Value {
+			return &object.Double{ Value: 1 }
+		}
+
+		return &object.Double{ Value: 0 }
+	default:
+		return newError("Cannot convert %s to a double", arg.Type())
+	}
+}
+
+func toBoolean(arg object.Object) object.Object {
+	switch arg := arg.(type) {
+	case *object.Boolean:
+		return arg
+	case *object.Null:
+		return FALSE
+	//	numbers are false when they are zero, and arrays and maps when they are empty
+	case *object.Integer:
+		return nativeBoolToBooleaObject(arg.Value != 0)
+	case *object.BigInt:
+		return nativeBoolToBooleaObject(arg.Value.Sign() != 0)
+	case *object.Double:
+		return nativeBoolToBooleaObject(arg.Value != 0)
+	case *object.Array:
+		return nativeBoolToBooleaObject(len(arg.Elements) != 0)
+	case *object.Map:
+		return nativeBoolToBooleaObject(arg.Len() != 0)
+	//	strings have to spell the boolean
+	case *object.String:
+		switch strings.TrimSpace(arg.Value) {
+		case "true":
+			return TRUE
+		case "false":
+			return FALSE
+		}
+
+		return newError("Cannot convert %q to a boolean", arg.Value)
+	default:
+		return newError("Cannot convert %s to a boolean", arg.Type())
+	}
+}
+
+//	typePredicate makes a builtin that checks if its argument is one of the given types
+func typePredicate(types ...object.ObjectType) *builtin {
+	return &builtin{
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			arg, err := oneArgument(args)
+			if err != nil {
+				return err
+			}
+
+			for _, t := range types {
+				if arg.Type() == t {
+					return TRUE
+				}
+			}
+
+			return FALSE
+		},
+	}
+}
+
+var conversionBuiltins = map[string]*builtin{
+	"int": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			arg, err := oneArgument(args)
+			if err != nil {
+				return err
+			}
+
+			return toInteger(arg)
+		},
+	},
+	"double": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			arg, err := oneArgument(args)
+			if err != nil {
+				return err
+			}
+
+			return toDouble(arg)
+		},
+	},
+	"string": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			arg, err := oneArgument(args)
+			if err != nil {
+				return err
+			}
+			//	values become the string they are printed as
+			return &object.String{ Value: arg.Inspect() }
+		},
+	},
+	"bool": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			arg, err := oneArgument(args)
+			if err != nil {
+				return err
+			}
+
+			return toBoolean(arg)
+		},
+	},
+	"typeOf": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			arg, err := oneArgument(args)
+			if err != nil {
+				return err
+			}
+
+			return &object.String{ Value: object.TypeName(arg) }
+		},
+	},
+	"isInt": typePredicate(object.INTEGER_OBJECT, object.BIGINT_OBJECT),
+	"isDouble": typePredicate(object.DOUBLE_OBJECT),
+	"isString": typePredicate(object.STRING_OBJECT),
+	"isBool": typePredicate(object.BOOLEAN_OBJECT),
+	"isArray": typePredicate(object.ARRAY_OBJECT),
+	"isMap": typePredicate(object.MAP_OBJECT),
+	"isFunction": typePredicate(object.FUNCTION_OBJECT, object.BUILTIN_OBJECT),
+	"isNull": typePredicate(object.NULL_OBJECT),
+}
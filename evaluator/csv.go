@@ -0,0 +1,204 @@
+package evaluator
+
+import (
+	"encoding/csv"
+	"language/object"
+	"strings"
+	"unicode/utf8"
+)
+
+//	csvSettings are the options csvParse and csvStringify take
+type csvSettings struct {
+	header bool
+	delimiter rune
+}
+
+//	csvOptions reads the options map, where "header" is a boolean and "delimiter" a single character
+func csvOptions(name string, arg object.Object, header bool) (csvSettings, *object.Error) {
+	settings := csvSettings{ header: header, delimiter: ',' }
+
+	options, ok := arg.(*object.Map)
+	if !ok {
+		return settings, newError("Options passed to `%s` must be a Map, got %s", name, arg.Type())
+	}
+
+	for _, pair := range options.Pairs() {
+		switch pair.Key.Inspect() {
+		case "header":
+			value, ok := pair.Value.(*object.Boolean)
+			if !ok {
+				return settings, newError("The header option of `%s` must be a Boolean, got %s", name, pair.Value.Type())
+			}
+
+			settings.header = value.Value
+		case "delimiter":
+			value, ok := pair.Value.(*object.String)
+			if !ok || utf8.RuneCountInString(value.Value) != 1 {
+				return settings, newError("The delimiter option of `%s` must be a single character", name)
+			}
+
+			settings.delimiter, _ = utf8.DecodeRuneInString(value.Value)
+		default:
+			return settings, newError("Unknown option passed to `%s`: %s", name, pair.Key.Inspect())
+		}
+	}
+
+	return settings, nil
+}
+
+//	csvField turns a value into the text of a field, with null being an empty field
+func csvField(value object.Object) string {
+	switch value := value.(type) {
+	case *object.String:
+		return value.Value
+	case *object.Null:
+		return ""
+	default:
+		return value.Inspect()
+	}
+}
+
+//	csvRecords turns the rows into records. Rows of maps are written in the order
+//	of the keys of the first one, which also make the header
+func csvRecords(rows *object.Array, header bool) ([][]string, *object.Error) {
+	records := [][]string{}
+	if len(rows.Elements) == 0 {
+		return records, nil
+	}
+
+	var keys []object.Object
+	if first, ok := rows.Elements[0].(*object.Map); ok {
+		names := []string{}
+		for _, pair := range first.Pairs() {
+			keys = append(keys, pair.Key)
+			names = append(names, csvField(pair.Key))
+		}
+
+		if header {
+			records = append(records, names)
+		}
+	}
+
+	for _, element := range rows.Elements {
+		switch row := element.(type) {
+		case *object.Array:
+			if keys != nil {
+				return nil, newError("Rows passed to `csvStringify` must all be Arrays or all be Maps")
+			}
+
+			record := make([]string, len(row.Elements))
+			for i, value := range row.Elements {
+				record[i] = csvField(value)
+			}
+
+			records = append(records, record)
+		case *object.Map:
+			if keys == nil {
+				return nil, newError("Rows passed to `csvStringify` must all be Arrays or all be Maps")
+			}
+			//	keys missing from a row are empty fields
+			record := make([]string, len(keys))
+			for i, key := range keys {
+				if value, ok := row.Get(key); ok {
+					record[i] = csvField(value)
+				}
+			}
+
+			records = append(records, record)
+		default:
+			return nil, newError("Rows passed to `csvStringify` must be Arrays or Maps, got %s", element.Type())
+		}
+	}
+
+	return records, nil
+}
+
+var csvBuiltins = map[string]*builtin{
+	"csvParse": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 1 && len(args) != 2 {
+				return newError("Wrong number of arguments. Expected 1 or 2, got %d", len(args))
+			}
+
+			text, ok := args[0].(*object.String)
+			if !ok {
+				return newError("First argument to `csvParse` must be a String, got %s", args[0].Type())
+			}
+
+			settings := csvSettings{ delimiter: ',' }
+			if len(args) == 2 {
+				var err *object.Error
+				if settings, err = csvOptions("csvParse", args[1], false); err != nil {
+					return err
+				}
+			}
+
+			reader := csv.NewReader(strings.NewReader(text.Value))
+			reader.Comma = settings.delimiter
+
+			records, err := reader.ReadAll()
+			if err != nil {
+				return newError("Could not parse CSV: %s", err)
+			}
+
+			rows := []object.Object{}
+			if !settings.header {
+				for _, record := range records {
+					rows = append(rows, stringArray(record))
+				}
+
+				return &object.Array{ Elements: rows }
+			}
+			//	with a header, each row is a map from the names in the first record to the fields
+			if len(records) == 0 {
+				return &object.Array{ Elements: rows }
+			}
+
+			for _, record := range records[1:] {
+				row := object.NewMap()
+				for i, name := range records[0] {
+					row.Set(&object.String{ Value: name }, &object.String{ Value: record[i] })
+				}
+
+				rows = append(rows, row)
+			}
+
+			return &object.Array{ Elements: rows }
+		},
+	},
+	"csvStringify": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 1 && len(args) != 2 {
+				return newError("Wrong number of arguments. Expected 1 or 2, got %d", len(args))
+			}
+
+			rows, ok := args[0].(*object.Array)
+			if !ok {
+				return newError("First argument to `csvStringify` must be an Array, got %s", args[0].Type())
+			}
+			//	rows of maps get a header unless it is turned off
+			settings := csvSettings{ header: true, delimiter: ',' }
+			if len(args) == 2 {
+				var err *object.Error
+				if settings, err = csvOptions("csvStringify", args[1], true); err != nil {
+					return err
+				}
+			}
+
+			records, err := csvRecords(rows, settings.header)
+			if err != nil {
+				return err
+			}
+
+			var out strings.Builder
+			writer := csv.NewWriter(&out)
+			writer.Comma = settings.delimiter
+
+			if writeErr := writer.WriteAll(records); writeErr != nil {
+				return newError("Could not write CSV: %s", writeErr)
+			}
+
+			return &object.String{ Value: out.String() }
+		},
+	},
+}
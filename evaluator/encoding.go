@@ -0,0 +1,93 @@
+package evaluator
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"language/object"
+	"net/url"
+)
+
+//	stringFunction makes a builtin that turns a string into another one
+func stringFunction(name string, fn func(string) string) *builtin {
+	return &builtin{
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			values, err := stringArguments(name, args, 1)
+			if err != nil {
+				return err
+			}
+
+			return &object.String{ Value: fn(values[0]) }
+		},
+	}
+}
+
+//	decodingFunction makes a builtin that decodes a string, failing if it is not valid
+func decodingFunction(name, encoding string, fn func(string) (string, error)) *builtin {
+	return &builtin{
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			values, err := stringArguments(name, args, 1)
+			if err != nil {
+				return err
+			}
+
+			decoded, decodeErr := fn(values[0])
+			if decodeErr != nil {
+				return newError("Could not decode %q as %s: %s", values[0], encoding, decodeErr)
+			}
+
+			return &object.String{ Value: decoded }
+		},
+	}
+}
+
+var encodingBuiltins = map[string]*builtin{
+	//	hashes are written as hexadecimal strings
+	"sha256": stringFunction("sha256", func(value string) string {
+		sum := sha256.Sum256([]byte(value))
+		return hex.EncodeToString(sum[:])
+	}),
+	"md5": stringFunction("md5", func(value string) string {
+		sum := md5.Sum([]byte(value))
+		return hex.EncodeToString(sum[:])
+	}),
+	"base64Encode": stringFunction("base64Encode", func(value string) string {
+		return base64.StdEncoding.EncodeToString([]byte(value))
+	}),
+	"base64Decode": decodingFunction("base64Decode", "base64", func(value string) (string, error) {
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		return string(decoded), err
+	}),
+	"hexEncode": stringFunction("hexEncode", func(value string) string {
+		return hex.EncodeToString([]byte(value))
+	}),
+	"hexDecode": decodingFunction("hexDecode", "hexadecimal", func(value string) (string, error) {
+		decoded, err := hex.DecodeString(value)
+		return string(decoded), err
+	}),
+	//	url encoding is the one used in query strings, with spaces as +
+	"urlEncode": stringFunction("urlEncode", url.QueryEscape),
+	"urlDecode": decodingFunction("urlDecode", "a URL", url.QueryUnescape),
+	"uuid": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 0 {
+				return newError("Wrong number of arguments. Got %d, expected 0", len(args))
+			}
+			//	a random (version 4) UUID
+			var id [16]byte
+			if _, err := rand.Read(id[:]); err != nil {
+				return newError("Could not make a UUID: %s", err)
+			}
+
+			id[6] = id[6] & 0x0f | 0x40
+			id[8] = id[8] & 0x3f | 0x80
+
+			return &object.String{
+				Value: fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:16]),
+			}
+		},
+	},
+}
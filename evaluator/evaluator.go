@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"language/ast"
 	"language/object"
+	"language/token"
+	"math"
+	"math/big"
 )
 
 var (
@@ -12,16 +15,16 @@ var (
 	FALSE = &object.Boolean{ Value: false }
 )
 
-func evalProgram(program *ast.Program, env *object.Environment) object.Object {
+func (in *Interpreter) evalProgram(program *ast.Program, env *object.Environment) object.Object {
 	var result object.Object
 
 	for _, statement := range program.Statements {
-		result = Eval(statement, env)
+		result = in.Eval(statement, env)
 
 		switch result := result.(type) {
 		case *object.ReturnValue:
 			return result.Value
-		case *object.Error:
+		case *object.Error, *object.Exit:
 			return result
 		}
 	}
@@ -29,17 +32,14 @@ func evalProgram(program *ast.Program, env *object.Environment) object.Object {
 	return result
 }
 
-func evalBlockStatement(block *ast.BlockStatement, env *object.Environment) object.Object {
+func (in *Interpreter) evalBlockStatement(block *ast.BlockStatement, env *object.Environment) object.Object {
 	var result object.Object
 
 	for _, statement := range block.Statements {
-		result = Eval(statement, env)
+		result = in.Eval(statement, env)
 
-		if result != nil {
-			rt := result.Type()
-			if rt == object.RETURN_VALUE_OBJECT || rt == object.ERROR_OBJECT {
-				return result
-			}
+		if isReturnOrError(result) {
+			return result
 		}
 	}
 
@@ -54,11 +54,23 @@ func isError(obj object.Object) bool {
 	return false
 }
 
-func evalStatements(statements []ast.Statement, env *object.Environment) object.Object {
+//	returns and errors stop the evaluation of the expression they are in
+//	and go up until the enclosing function or the program handles them.
+//	Exits do the same, but nothing handles them until the program ends
+func isReturnOrError(obj object.Object) bool {
+	if obj != nil {
+		rt := obj.Type()
+		return rt == object.RETURN_VALUE_OBJECT || rt == object.ERROR_OBJECT || rt == object.EXIT_OBJECT
+	}
+
+	return false
+}
+
+func (in *Interpreter) evalStatements(statements []ast.Statement, env *object.Environment) object.Object {
 	var result object.Object
 	//	evaluates the statements recursively
 	for _, statement := range statements {
-		result = Eval(statement, env)
+		result = in.Eval(statement, env)
 
 		if returnValue, ok := result.(*object.ReturnValue); ok {
 			return returnValue.Value
@@ -87,12 +99,23 @@ func evalNotOperatorExpression(right object.Object) object.Object {
 }
 
 func evalMinusPrefixOperatorExpression(right object.Object) object.Object {
+	//	big integers are negated as big integers
+	if bigInt, ok := right.(*object.BigInt); ok {
+		return normalizeBigInt(new(big.Int).Neg(bigInt.Value))
+	}
+	if double, ok := right.(*object.Double); ok {
+		return &object.Double{ Value: -double.Value }
+	}
 	//	check if the object passed is an integer
 	if right.Type() != object.INTEGER_OBJECT {
 		return newError("Unknown operator: -%s", right.Type())
 	}
 	//	retrieve the value passed on the right of the minus operand
 	value := right.(*object.Integer).Value
+	//	the smallest integer has no positive counterpart, so it becomes a big integer
+	if value == math.MinInt64 {
+		return normalizeBigInt(new(big.Int).Neg(big.NewInt(value)))
+	}
 	//	return a new object integer with the negative value
 	return &object.Integer{ Value: -value }
 }
@@ -114,15 +137,41 @@ func evalIntegerInfixExpression(operator string, left, right object.Object) obje
 
 	switch operator {
 	case "+":
-		return &object.Integer{ Value: leftValue + rightValue }
+		result := leftValue + rightValue
+		//	adding two numbers of the same sign cannot change the sign unless it overflows
+		if (leftValue > 0 && rightValue > 0 && result < 0) ||
+			(leftValue < 0 && rightValue < 0 && result >= 0) {
+			return evalBigIntInfixExpression(operator, left, right)
+		}
+		return &object.Integer{ Value: result }
 	case "-":
-		return &object.Integer{ Value: leftValue - rightValue }
+		result := leftValue - rightValue
+		//	subtracting numbers of different signs overflows if the sign of the left one changes
+		if (leftValue >= 0 && rightValue < 0 && result < 0) ||
+			(leftValue < 0 && rightValue > 0 && result >= 0) {
+			return evalBigIntInfixExpression(operator, left, right)
+		}
+		return &object.Integer{ Value: result }
 	case "*":
-		return &object.Integer{ Value: leftValue * rightValue }
+		if leftValue == 0 || rightValue == 0 {
+			return &object.Integer{ Value: 0 }
+		}
+		result := leftValue * rightValue
+		//	the product overflowed if it cannot be divided back into the left value
+		if result / rightValue != leftValue ||
+			(leftValue == -1 && rightValue == math.MinInt64) ||
+			(rightValue == -1 && leftValue == math.MinInt64) {
+			return evalBigIntInfixExpression(operator, left, right)
+		}
+		return &object.Integer{ Value: result }
 	case "/":
 		if rightValue == 0 {
 			return newError("Error: division by zero not supported")
 		}
+		//	the smallest integer divided by -1 does not fit in an integer
+		if leftValue == math.MinInt64 && rightValue == -1 {
+			return evalBigIntInfixExpression(operator, left, right)
+		}
 		return &object.Integer{ Value: leftValue / rightValue }
 	case "==":
 		return nativeBoolToBooleaObject(leftValue == rightValue)
@@ -141,6 +190,115 @@ func evalIntegerInfixExpression(operator string, left, right object.Object) obje
 	}
 }
 
+func isInteger(obj object.Object) bool {
+	return obj.Type() == object.INTEGER_OBJECT || obj.Type() == object.BIGINT_OBJECT
+}
+
+func toBigInt(obj object.Object) *big.Int {
+	if bigInt, ok := obj.(*object.BigInt); ok {
+		return bigInt.Value
+	}
+
+	return big.NewInt(obj.(*object.Integer).Value)
+}
+
+func normalizeBigInt(value *big.Int) object.Object {
+	//	results that fit in an integer go back to being integers
+	if value.IsInt64() {
+		return &object.Integer{ Value: value.Int64() }
+	}
+
+	return &object.BigInt{ Value: value }
+}
+
+func evalBigIntInfixExpression(operator string, left, right object.Object) object.Object {
+	leftValue := toBigInt(left)
+	rightValue := toBigInt(right)
+
+	switch operator {
+	case "+":
+		return normalizeBigInt(new(big.Int).Add(leftValue, rightValue))
+	case "-":
+		return normalizeBigInt(new(big.Int).Sub(leftValue, rightValue))
+	case "*":
+		return normalizeBigInt(new(big.Int).Mul(leftValue, rightValue))
+	case "/":
+		if rightValue.Sign() == 0 {
+			return newError("Error: division by zero not supported")
+		}
+		//	Quo truncates towards zero like the integer division does
+		return normalizeBigInt(new(big.Int).Quo(leftValue, rightValue))
+	case "==":
+		return nativeBoolToBooleaObject(leftValue.Cmp(rightValue) == 0)
+	case "!=":
+		return nativeBoolToBooleaObject(leftValue.Cmp(rightValue) != 0)
+	case ">":
+		return nativeBoolToBooleaObject(leftValue.Cmp(rightValue) > 0)
+	case "<":
+		return nativeBoolToBooleaObject(leftValue.Cmp(rightValue) < 0)
+	case "<=":
+		return nativeBoolToBooleaObject(leftValue.Cmp(rightValue) <= 0)
+	case ">=":
+		return nativeBoolToBooleaObject(leftValue.Cmp(rightValue) >= 0)
+	default:
+		return newError("Unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+func isNumber(obj object.Object) bool {
+	return isInteger(obj) || obj.Type() == object.DOUBLE_OBJECT
+}
+
+//	toFloat returns the value of a number as a float
+func toFloat(obj object.Object) float64 {
+	switch obj := obj.(type) {
+	case *object.Double:
+		return obj.Value
+	case *object.Integer:
+		return float64(obj.Value)
+	case *object.BigInt:
+		value, _ := new(big.Float).SetInt(obj.Value).Float64()
+		return value
+	}
+
+	return 0
+}
+
+//	evalDoubleInfixExpression evaluates operations with at least one double,
+//	turning the other number into a double too
+func evalDoubleInfixExpression(operator string, left, right object.Object) object.Object {
+	leftValue := toFloat(left)
+	rightValue := toFloat(right)
+
+	switch operator {
+	case "+":
+		return &object.Double{ Value: leftValue + rightValue }
+	case "-":
+		return &object.Double{ Value: leftValue - rightValue }
+	case "*":
+		return &object.Double{ Value: leftValue * rightValue }
+	case "/":
+		if rightValue == 0 {
+			return newError("Error: division by zero not supported")
+		}
+		return &object.Double{ Value: leftValue / rightValue }
+	case "==":
+		return nativeBoolToBooleaObject(leftValue == rightValue)
+	case "!=":
+		return nativeBoolToBooleaObject(leftValue != rightValue)
+	case ">":
+		return nativeBoolToBooleaObject(leftValue > rightValue)
+	case "<":
+		return nativeBoolToBooleaObject(leftValue < rightValue)
+	case "<=":
+		return nativeBoolToBooleaObject(leftValue <= rightValue)
+	case ">=":
+		return nativeBoolToBooleaObject(leftValue >= rightValue)
+	default:
+		return newError("Unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
 func evalStringInfixExpression(operator string, left, right object.Object) object.Object {
 	//	gets both values
 	leftVal := left.(*object.String).Value
@@ -153,6 +311,15 @@ func evalStringInfixExpression(operator string, left, right object.Object) objec
 	case "+":
 		//	creates the object with the concatenated strings
 		return &object.String{ Value: leftVal + rightVal }
+	//	strings are compared alphabetically
+	case "<":
+		return nativeBoolToBooleaObject(leftVal < rightVal)
+	case ">":
+		return nativeBoolToBooleaObject(leftVal > rightVal)
+	case "<=":
+		return nativeBoolToBooleaObject(leftVal <= rightVal)
+	case ">=":
+		return nativeBoolToBooleaObject(leftVal >= rightVal)
 	default:
 		return newError("Unknown operator: %s %s %s", left.Type(), operator, right.Type())
 	}
@@ -201,12 +368,22 @@ func evalInfixExpression(
 	switch {
 	case left.Type() == object.INTEGER_OBJECT && right.Type() == object.INTEGER_OBJECT:
 		return evalIntegerInfixExpression(operator, left, right)
+	case isInteger(left) && isInteger(right):
+		return evalBigIntInfixExpression(operator, left, right)
+	case isNumber(left) && isNumber(right):
+		return evalDoubleInfixExpression(operator, left, right)
 	case left.Type() == object.STRING_OBJECT && right.Type() == object.STRING_OBJECT:
 		return evalStringInfixExpression(operator, left, right)
+	//	adding a string and any other value joins the string with how the value is printed,
+	//	times included, so it goes before the time operators
+	case operator == "+" && (left.Type() == object.STRING_OBJECT || right.Type() == object.STRING_OBJECT):
+		return &object.String{ Value: left.Inspect() + right.Inspect() }
+	case left.Type() == object.TIME_OBJECT || right.Type() == object.TIME_OBJECT:
+		return evalTimeInfixExpression(operator, left, right)
 	case operator == "==":
-		return nativeBoolToBooleaObject(left == right)
+		return nativeBoolToBooleaObject(object.Equal(left, right))
 	case operator == "!=":
-		return nativeBoolToBooleaObject(left != right)
+		return nativeBoolToBooleaObject(!object.Equal(left, right))
 	case operator == "&&":
 		return evalBooleanInfixExpression(operator, left, right)
 	case operator == "||":
@@ -231,17 +408,17 @@ func isTruthy(obj object.Object) bool {
 	}
 }
 
-func evalIfExpression(ie *ast.IfExpression, env *object.Environment) object.Object {
-	condition := Eval(ie.Condition, env)
+func (in *Interpreter) evalIfExpression(ie *ast.IfExpression, env *object.Environment) object.Object {
+	condition := in.Eval(ie.Condition, env)
 	//	checks if the condition has an error
-	if isError(condition) {
+	if isReturnOrError(condition) {
 		return condition
 	}
 
 	if isTruthy(condition) {
-		return Eval(ie.Consequence, env)
+		return in.Eval(ie.Consequence, env)
 	} else if ie.Alternative != nil {
-		return Eval(ie.Alternative, env)
+		return in.Eval(ie.Alternative, env)
 	} else {
 		return NULL
 	}
@@ -251,26 +428,50 @@ func newError(format string, a ...interface{}) *object.Error {
 	return &object.Error{ Message: fmt.Sprintf(format, a...) }
 }
 
-func evalIdentifier(node *ast.Identifier, env *object.Environment) object.Object {
+func withPosition(obj object.Object, tok token.Token) object.Object {
+	//	errors take the position of the first node that sees them,
+	//	which is the innermost one, where the error was raised
+	if err, ok := obj.(*object.Error); ok && err.Line == 0 {
+		err.Line = tok.Line
+		err.Column = tok.Column
+	}
+
+	return obj
+}
+
+func functionName(node ast.Expression) string {
+	//	function literals called directly have no name to show
+	if _, ok := node.(*ast.FunctionLiteral); ok {
+		return "anonymous function"
+	}
+
+	return node.String()
+}
+
+func (in *Interpreter) evalIdentifier(node *ast.Identifier, env *object.Environment) object.Object {
 
 	if val, ok := env.Get(node.Value); ok {
 		return val
 	}
 
-	if builtIn, ok := builtins[node.Value]; ok {
+	if builtIn, ok := in.builtins[node.Value]; ok {
 		return builtIn
 	}
 
-	return newError("%s", "Identifier not found: " + node.Value)
+	if constant, ok := constants[node.Value]; ok {
+		return constant
+	}
+
+	return withPosition(newError("%s", "Identifier not found: " + node.Value), node.Token)
 }
 
-func evalExpressions(expressions []ast.Expression, env *object.Environment) []object.Object {
+func (in *Interpreter) evalExpressions(expressions []ast.Expression, env *object.Environment) []object.Object {
 	var result []object.Object
 
 	for _, e := range expressions {
-		evaluated := Eval(e, env)
+		evaluated := in.Eval(e, env)
 
-		if isError(evaluated) {
+		if isReturnOrError(evaluated) {
 			return []object.Object{evaluated}
 		}
 
@@ -298,57 +499,118 @@ func unwrapReturnValue(obj object.Object) object.Object {
 	return obj
 }
 
-func applyFunction(fn object.Object, args []object.Object) object.Object {
+func (in *Interpreter) applyFunction(
+	fn object.Object,
+	args []object.Object,
+	call *ast.CallExpression,
+) object.Object {
+	var result object.Object
+
 	switch function := fn.(type) {
 	case *object.Function:
+		if len(args) < len(function.Parameters) {
+			result = newError("Wrong number of arguments. Got %d, expected %d", len(args), len(function.Parameters))
+			break
+		}
+
 		extendedEnv := extendFunctionEnv(function, args)
-		evaluated := Eval(function.Body, extendedEnv)
-		return unwrapReturnValue(evaluated)
+		in.calls += 1
+		evaluated := in.Eval(function.Body, extendedEnv)
+		in.calls -= 1
+		result = unwrapReturnValue(evaluated)
 	case *object.BuiltIn:
-		return function.Fn(args...)
+		result = function.Fn(args...)
 	default:
-		return newError("Not a function: %s", function.Type())
+		if call == nil {
+			return newError("Not a function: %s", function.Type())
+		}
+
+		return withPosition(newError("Not a function: %s", function.Type()), call.Token)
+	}
+	//	functions called back by builtins have no call expression,
+	//	the error is added to the stack by the call to the builtin
+	if call == nil {
+		return result
+	}
+	//	if the call failed, it is added to the stack of the error
+	if err, ok := result.(*object.Error); ok {
+		withPosition(err, call.Token)
+		err.Stack = append(err.Stack, object.Frame{
+			Function: functionName(call.Function),
+			Line: call.Token.Line,
+			Column: call.Token.Column,
+		})
+	}
+
+	return result
+}
+
+//	resolveIndex turns a negative index into one counted from the end,
+//	and checks that it is inside a sequence of the given length
+func resolveIndex(idx int64, length int) (int64, bool) {
+	if idx < 0 {
+		idx += int64(length)
 	}
+
+	return idx, idx >= 0 && idx < int64(length)
+}
+
+func (in *Interpreter) indexOutOfRange(idx int64, length int) object.Object {
+	//	out of range indexes are null, unless the interpreter is strict about them
+	if in.StrictIndexing {
+		return newError("Index out of range: %d (length %d)", idx, length)
+	}
+
+	return NULL
 }
 
-func evalArrayIndexExpression(array, index object.Object) object.Object {
+func (in *Interpreter) evalArrayIndexExpression(array, index object.Object) object.Object {
 	//	gets the array object
 	arrayObject := array.(*object.Array)
-	//	gets the index
-	idx := index.(*object.Integer).Value
-	//	gets the length of the array passed
-	max := int64(len(arrayObject.Elements) - 1)
-	//	checks if the index requested is between 0 and the length of the array
-	//	if so, the index is out of range and returns null
-	if idx < 0 || idx > max {
-		return NULL
+	//	gets the index, counting from the end if it is negative
+	idx, ok := resolveIndex(index.(*object.Integer).Value, len(arrayObject.Elements))
+
+	if !ok {
+		return in.indexOutOfRange(index.(*object.Integer).Value, len(arrayObject.Elements))
 	}
 	//	returns the object at that position
 	return arrayObject.Elements[idx]
 }
 
+func (in *Interpreter) evalStringIndexExpression(str, index object.Object) object.Object {
+	//	strings are indexed by character
+	chars := []rune(str.(*object.String).Value)
+	idx, ok := resolveIndex(index.(*object.Integer).Value, len(chars))
+
+	if !ok {
+		return in.indexOutOfRange(index.(*object.Integer).Value, len(chars))
+	}
+
+	return &object.String{ Value: string(chars[idx]) }
+}
+
 func evalMapIndexExpression(mapObj, index object.Object) object.Object {
 	mapObject := mapObj.(*object.Map)
 
-	key, ok := index.(object.Mapable)
-
-	if !ok {
+	if !object.Hashable(index) {
 		return newError("Unsupported as map key: %s", index.Type())
 	}
 
-	pair, ok := mapObject.Pairs[key.MapKey()]
+	value, ok := mapObject.Get(index)
 
 	if !ok {
 		return NULL
 	}
 
-	return pair.Value
+	return value
 }
 
-func evalIndexExpression(left, index object.Object) object.Object {
+func (in *Interpreter) evalIndexExpression(left, index object.Object) object.Object {
 	switch {
 	case left.Type() == object.ARRAY_OBJECT && index.Type() == object.INTEGER_OBJECT:
-		return evalArrayIndexExpression(left, index)
+		return in.evalArrayIndexExpression(left, index)
+	case left.Type() == object.STRING_OBJECT && index.Type() == object.INTEGER_OBJECT:
+		return in.evalStringIndexExpression(left, index)
 	case left.Type() == object.MAP_OBJECT:
 		return evalMapIndexExpression(left, index)
 	default:
@@ -356,66 +618,274 @@ func evalIndexExpression(left, index object.Object) object.Object {
 	}
 }
 
-func evalMapLiteral(node *ast.MapLiteral, env *object.Environment) object.Object {
-	pairs := make(map[object.MapKey]object.MapPair)
+//	sliceBound evaluates a bound of a slice, defaulting to the given value when it is left out.
+//	Negative bounds count from the end, and bounds outside the sequence are clamped to it
+func (in *Interpreter) sliceBound(node ast.Expression, env *object.Environment, fallback int64, length int) (int64, object.Object) {
+	if node == nil {
+		return fallback, nil
+	}
+
+	bound := in.Eval(node, env)
+	if isReturnOrError(bound) {
+		return 0, bound
+	}
+
+	integer, ok := bound.(*object.Integer)
+	if !ok {
+		return 0, newError("Slice bounds must be Integers, got %s", bound.Type())
+	}
+
+	idx := integer.Value
+	if idx < 0 {
+		idx += int64(length)
+	}
+
+	return max(0, min(idx, int64(length))), nil
+}
+
+func (in *Interpreter) evalSliceExpression(node *ast.SliceExpression, env *object.Environment) object.Object {
+	left := in.Eval(node.Left, env)
+	if isReturnOrError(left) {
+		return left
+	}
+
+	var length int
+	var chars []rune
+
+	switch left := left.(type) {
+	case *object.Array:
+		length = len(left.Elements)
+	case *object.String:
+		chars = []rune(left.Value)
+		length = len(chars)
+	default:
+		return withPosition(newError("Slice operator not supported: %s", left.Type()), node.Token)
+	}
+
+	start, err := in.sliceBound(node.Start, env, 0, length)
+	if err != nil {
+		return withPosition(err, node.Token)
+	}
+
+	end, err := in.sliceBound(node.End, env, int64(length), length)
+	if err != nil {
+		return withPosition(err, node.Token)
+	}
+	//	a start after the end makes an empty slice
+	if start > end {
+		start = end
+	}
+	//	slices are copies, changing them leaves the original untouched
+	if array, ok := left.(*object.Array); ok {
+		elements := make([]object.Object, end - start)
+		copy(elements, array.Elements[start:end])
+
+		return &object.Array{ Elements: elements }
+	}
 
-	for keyNode, valueNode := range node.Pairs {
+	return &object.String{ Value: string(chars[start:end]) }
+}
+
+func (in *Interpreter) evalMapLiteral(node *ast.MapLiteral, env *object.Environment) object.Object {
+	mapObject := object.NewMap()
+	//	the keys are evaluated in the order they were written, which is the order of the map
+	for _, keyNode := range node.Keys {
+		valueNode := node.Pairs[keyNode]
 		//	evaluating that the key is valid
-		key := Eval(keyNode, env)
-		if isError(key) {
+		key := in.Eval(keyNode, env)
+		if isReturnOrError(key) {
 			return key
 		}
 		//	checking that the key conforms to mapable
-		mapKey, ok := key.(object.Mapable)
 		//	if not, throw an error
-		if !ok {
+		if !object.Hashable(key) {
 			return newError("Unusable as a map key: %s", key.Type())
 		}
 		//	evaluating that the value is valid
-		value := Eval(valueNode, env)
+		value := in.Eval(valueNode, env)
 		//	if not throw an error
-		if isError(value) {
+		if isReturnOrError(value) {
 			return value
 		}
-		//	gets the map key
-		mapped := mapKey.MapKey()
-		//	assigns the pair to the key found in mapped
-		pairs[mapped] = object.MapPair{ Key: key, Value: value}
+		//	assigns the value to the key
+		mapObject.Set(key, value)
 	}
-	//	returns a new map object with the newly formed pairs
-	return &object.Map{ Pairs: pairs }
+	//	returns the map object with the newly formed pairs
+	return mapObject
 }
 
-func evalForStatement(node *ast.ForStatement, env *object.Environment) object.Object {
-	//	evaluating the condition
-	condition := Eval(node.Condition, env)
-	//	if an error is found, return it
-	if isError(condition) {
-		return condition
+func (in *Interpreter) evalForStatement(node *ast.ForStatement, env *object.Environment) object.Object {
+	for {
+		//	evaluating the condition
+		condition := in.Eval(node.Condition, env)
+		//	if an error is found, return it
+		if isReturnOrError(condition) {
+			return condition
+		}
+		//	once the condition is not met, the loop ends
+		if !isTruthy(condition) {
+			return NULL
+		}
+		//	evaluates the body of the loop
+		result := in.Eval(node.Body, env)
+		//	returns and errors stop the loop and go up to the enclosing block
+		if isReturnOrError(result) {
+			return result
+		}
 	}
+}
 
-	if isTruthy(condition) {
-		//	if the condition is met, evaluate the body of the loop
-		Eval(node.Body, env)
-		//	re-evaluate the loop
-		Eval(node, env)
+//	kinds given to errors that do not have one
+const (
+	RUNTIME_ERROR = "RuntimeError"
+	THROWN_ERROR = "Error"
+	IO_ERROR = "IOError"
+)
+
+func setMapValue(m *object.Map, key string, value object.Object) {
+	m.Set(&object.String{ Value: key }, value)
+}
+
+func getMapValue(m *object.Map, key string) (object.Object, bool) {
+	return m.Get(&object.String{ Value: key })
+}
+
+func newErrorMap(message, kind string, stack []object.Frame) *object.Map {
+	errorMap := object.NewErrorMap()
+	//	each frame of the stack is a map with the function name and the call position
+	frames := []object.Object{}
+	for _, frame := range stack {
+		frameMap := object.NewMap()
+		setMapValue(frameMap, "function", &object.String{ Value: frame.Function })
+		setMapValue(frameMap, "line", &object.Integer{ Value: int64(frame.Line) })
+		setMapValue(frameMap, "column", &object.Integer{ Value: int64(frame.Column) })
+		frames = append(frames, frameMap)
 	}
 
-	return NULL
+	setMapValue(errorMap, "message", &object.String{ Value: message })
+	setMapValue(errorMap, "kind", &object.String{ Value: kind })
+	setMapValue(errorMap, "stack", &object.Array{ Elements: frames })
+
+	return errorMap
+}
+
+func errorToMap(err *object.Error) *object.Map {
+	kind := err.Kind
+	if kind == "" {
+		kind = RUNTIME_ERROR
+	}
+
+	return newErrorMap(err.Message, kind, err.Stack)
+}
+
+func (in *Interpreter) evalThrowStatement(node *ast.ThrowStatement, env *object.Environment) object.Object {
+	value := in.Eval(node.Value, env)
+	if isReturnOrError(value) {
+		return value
+	}
+
+	err := &object.Error{ Kind: THROWN_ERROR, Line: node.Token.Line, Column: node.Token.Column }
+
+	switch value := value.(type) {
+	case *object.String:
+		err.Message = value.Value
+	case *object.Map:
+		fillErrorFromMap(err, value)
+	default:
+		err.Message = value.Inspect()
+	}
+
+	return err
+}
+
+//	fillErrorFromMap gives the error the message and kind of the map,
+//	so maps made by error() or caught by a catch block keep them
+func fillErrorFromMap(err *object.Error, m *object.Map) {
+	message, ok := getMapValue(m, "message")
+	if !ok {
+		err.Message = m.Inspect()
+		return
+	}
+	err.Message = message.Inspect()
+
+	if kind, ok := getMapValue(m, "kind"); ok {
+		err.Kind = kind.Inspect()
+	}
+}
+
+func isErrorMap(obj object.Object) bool {
+	//	error maps are the ones made by error(), caught by a catch block or returned by ?,
+	//	so maps with the same keys made by the program are not mistaken for them
+	errorMap, ok := obj.(*object.Map)
+	return ok && errorMap.IsError()
+}
+
+func (in *Interpreter) evalPropagateExpression(node *ast.PropagateExpression, env *object.Environment) object.Object {
+	value := in.Eval(node.Left, env)
+
+	switch {
+	case value != nil && value.Type() == object.RETURN_VALUE_OBJECT:
+		return value
+	//	outside of a function there is nothing to return from, so the error stays uncaught
+	case isError(value) && in.calls == 0:
+		return value
+	case isErrorMap(value) && in.calls == 0:
+		err := &object.Error{ Kind: THROWN_ERROR }
+		fillErrorFromMap(err, value.(*object.Map))
+		return withPosition(err, node.Token)
+	case isError(value):
+		//	failed calls return from the current function with the error as a value
+		return &object.ReturnValue{ Value: errorToMap(value.(*object.Error)) }
+	case isErrorMap(value):
+		return &object.ReturnValue{ Value: value }
+	default:
+		return value
+	}
+}
+
+func (in *Interpreter) evalTryStatement(node *ast.TryStatement, env *object.Environment) object.Object {
+	result := in.Eval(node.Block, env)
+
+	if err, ok := result.(*object.Error); ok && node.Catch != nil {
+		//	the caught error is available as a map only inside the catch block,
+		//	so it does not clash with or outlive the variables around the try
+		catchEnv := object.NewBlockEnvironment(env)
+		catchEnv.Set(node.Parameter.Value, errorToMap(err))
+		result = in.Eval(node.Catch, catchEnv)
+	}
+
+	if node.Finally != nil {
+		//	the finally block always runs, and if it returns or fails
+		//	that replaces the result of the try and catch blocks
+		finally := in.Eval(node.Finally, env)
+		if isReturnOrError(finally) {
+			return finally
+		}
+	}
+
+	if result == nil {
+		return NULL
+	}
+
+	return result
 }
 
-func evalReassignmentStatement(
+func (in *Interpreter) evalReassignmentStatement(
 	node *ast.ReassignStatement,
 	env *object.Environment,
 ) object.Object {
-	val := Eval(node.Value, env)
+	val := in.Eval(node.Value, env)
 
 	if val == nil {
 		return nil
 	}
 
+	if isReturnOrError(val) {
+		return val
+	}
+
 	if _, ok := env.Get(node.Name.Value); ok {
-		reassignment := env.Set(node.Name.Value, val)
+		reassignment := env.Scope(node.Name.Value).Set(node.Name.Value, val)
 
 		if isError(reassignment) {
 			return reassignment
@@ -428,105 +898,136 @@ func evalReassignmentStatement(
 	return val
 }
 
-func Eval(node ast.Node, env *object.Environment) object.Object {
+func (in *Interpreter) Eval(node ast.Node, env *object.Environment) object.Object {
 	switch node := node.(type) {
 	//	Statements
 	case *ast.Program:
-		return evalProgram(node, env)
+		return in.evalProgram(node, env)
 	case *ast.ExpressionStatement:
-		return Eval(node.Expression, env)
+		return in.Eval(node.Expression, env)
 	case *ast.BlockStatement:
-		return evalBlockStatement(node, env)
+		return in.evalBlockStatement(node, env)
 	case *ast.ReturnStatement:
-		val := Eval(node.ReturnValue, env)
-		//	if an error is found on the val variable, return val, which contains an error
-		if isError(val) {
+		val := in.Eval(node.ReturnValue, env)
+		//	if an error or an early return is found on the val variable, return val
+		if isReturnOrError(val) {
 			return val
 		}
 		return &object.ReturnValue{ Value: val }
 	case *ast.VarStatement:
-		val := Eval(node.Value, env)
-		if isError(val) {
+		val := in.Eval(node.Value, env)
+		if isReturnOrError(val) {
 			return val
 		}
+		//	variables declared with the name of a struct only take records of it
+		if node.Type.Type == token.IDENTIFIER && !typeMatches(node.Type.Literal, val) {
+			return withPosition(newError("Expected type %s, got %s", node.Type.Literal, object.TypeName(val)), node.Type)
+		}
 		env.Set(node.Name.Value, val)
-		return Eval(node.Name, env)
+		return in.Eval(node.Name, env)
 	case *ast.ConstStatement:
-		val := Eval(node.Value, env)
-		if isError(val) {
+		val := in.Eval(node.Value, env)
+		if isReturnOrError(val) {
 			return val
 		}
+		if node.Type.Type == token.IDENTIFIER && !typeMatches(node.Type.Literal, val) {
+			return withPosition(newError("Expected type %s, got %s", node.Type.Literal, object.TypeName(val)), node.Type)
+		}
 		env.Set(node.Name.Value, val)
-		return Eval(node.Value, env)
+		return val
 	case *ast.ReassignStatement:
-		return evalReassignmentStatement(node, env)
+		return withPosition(in.evalReassignmentStatement(node, env), node.Token)
 	case *ast.Identifier:
-		return evalIdentifier(node, env)
+		return in.evalIdentifier(node, env)
 	case *ast.ForStatement:
-		return evalForStatement(node, env)
+		return in.evalForStatement(node, env)
+	case *ast.TryStatement:
+		return in.evalTryStatement(node, env)
+	case *ast.ThrowStatement:
+		return in.evalThrowStatement(node, env)
+	case *ast.ImportStatement:
+		return in.evalImportStatement(node, env)
+	case *ast.ExportStatement:
+		return in.Eval(node.Statement, env)
+	case *ast.StructStatement:
+		return in.evalStructStatement(node, env)
 	//	Expressions
 	case *ast.IntegerLiteral:
+		//	the big value is copied so the result does not share it with the literal
+		if node.Big != nil {
+			return &object.BigInt{ Value: new(big.Int).Set(node.Big) }
+		}
 		return &object.Integer{ Value: node.Value }
+	case *ast.DoubleLiteral:
+		return &object.Double{ Value: node.Value }
 	case *ast.Boolean:
 		return nativeBoolToBooleaObject(node.Value)
 	case *ast.PrefixExpression:
-		right := Eval(node.Right, env)
-		if isError(right) {
+		right := in.Eval(node.Right, env)
+		if isReturnOrError(right) {
 			return right
 		}
-		return evalPrefixExpression(node.Operator, right)
+		return withPosition(evalPrefixExpression(node.Operator, right), node.Token)
 	case *ast.InfixExpression:
-		left := Eval(node.Left, env)
-		if isError(left) {
+		left := in.Eval(node.Left, env)
+		if isReturnOrError(left) {
 			return left
 		}
 
-		right := Eval(node.Right, env)
-		if isError(right) {
+		right := in.Eval(node.Right, env)
+		if isReturnOrError(right) {
 			return right
 		}
-		return evalInfixExpression(node.Operator, left, right)
+		return withPosition(evalInfixExpression(node.Operator, left, right), node.Token)
 	case *ast.IfExpression:
-		return evalIfExpression(node, env)
+		return in.evalIfExpression(node, env)
+	case *ast.PropagateExpression:
+		return in.evalPropagateExpression(node, env)
 	case *ast.FunctionLiteral:
 		parameters := node.Parameters
 		body := node.Body
 		return &object.Function{ Parameters: parameters, Body: body, Env: env }
 	case *ast.CallExpression:
-		function := Eval(node.Function, env)
-		if isError(function) {
+		function := in.Eval(node.Function, env)
+		if isReturnOrError(function) {
 			return function
 		}
 
-		args := evalExpressions(node.Arguments, env)
-		if len(args) == 1 && isError(args[0]) {
+		args := in.evalExpressions(node.Arguments, env)
+		if len(args) == 1 && isReturnOrError(args[0]) {
 			return args[0]
 		}
 
-		return applyFunction(function, args)
+		return in.applyFunction(function, args, node)
 	case *ast.StringLiteral:
 		return &object.String{ Value: node.Value }
 	case *ast.ArrayLiteral:
-		elements := evalExpressions(node.Elements, env)
-		if len(elements) == 1 && isError(elements[0]) {
+		elements := in.evalExpressions(node.Elements, env)
+		if len(elements) == 1 && isReturnOrError(elements[0]) {
 			return elements[0]
 		}
 
 		return &object.Array{ Elements: elements }
 	case *ast.IndexExpression:
-		left := Eval(node.Left, env)
-		if isError(left) {
+		left := in.Eval(node.Left, env)
+		if isReturnOrError(left) {
 			return left
 		}
 
-		index := Eval(node.Index, env)
-		if isError(index) {
+		index := in.Eval(node.Index, env)
+		if isReturnOrError(index) {
 			return index
 		}
 
-		return evalIndexExpression(left, index)
+		return withPosition(in.evalIndexExpression(left, index), node.Token)
+	case *ast.SliceExpression:
+		return in.evalSliceExpression(node, env)
+	case *ast.MemberExpression:
+		return in.evalMemberExpression(node, env)
+	case *ast.StructLiteral:
+		return in.evalStructLiteral(node, env)
 	case *ast.MapLiteral:
-		return evalMapLiteral(node, env)
+		return withPosition(in.evalMapLiteral(node, env), node.Token)
 	}
 
 	return nil
@@ -1,10 +1,15 @@
 package evaluator
 
 import (
+	"bytes"
 	"language/lexer"
 	"language/object"
 	"language/parser"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestEvalIntegerExpression(t *testing.T) {
@@ -365,6 +370,48 @@ func TestBuiltInFunctions(t *testing.T) {
 	}
 }
 
+func TestMapBuiltInFunctions(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`keys({"b": 1, "a": 2})`, "[b, a]"},
+		{`values({"b": 1, "a": 2})`, "[1, 2]"},
+		{`entries({"b": 1, "a": 2})`, "[[b, 1], [a, 2]]"},
+		{`keys({})`, "[]"},
+		{`has({"a": 1}, "a")`, "true"},
+		{`has({"a": 1}, "b")`, "false"},
+		{`has({[1, 2]: 1}, [1, 2])`, "true"},
+		{`var map m = {"a": 1, "b": 2}; delete(m, "a"); m;`, "{b: 2}"},
+		{`var map m = {"a": 1}; delete(m, "missing"); m;`, "{a: 1}"},
+		{`var map m = {"a": 1}; set(m, "b", 2); set(m, "a", 3); m;`, "{a: 3, b: 2}"},
+		{`merge({"a": 1, "b": 2}, {"b": 3, "c": 4})`, "{a: 1, b: 3, c: 4}"},
+		{`var map a = {"x": 1}; merge(a, {"y": 2}); a;`, "{x: 1}"},
+		{`length({"a": 1, "b": 2})`, "2"},
+		{`length({})`, "0"},
+		{`keys([1])`, "Argument to `keys` must be a Map, got ARRAY"},
+		{`has({}, func(x) { x })`, "Unsupported as map key: FUNCTION"},
+		{`set({}, "a")`, "Wrong number of arguments. Got 2, expected 3"},
+		{`var array a = [1]; push(a, a); set({}, a, 1);`, "Unsupported as map key: ARRAY"},
+		{`merge({}, [])`, "Second argument to `merge` must be a Map, got ARRAY"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		if errObj, ok := evaluated.(*object.Error); ok {
+			if errObj.Message != tt.expected {
+				t.Errorf("%s: wrong error message. Expected %q, got %q", tt.input, tt.expected, errObj.Message)
+			}
+			continue
+		}
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: expected %s, got %s", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
 func TestArrayLiterals(t *testing.T) {
 	input := "[1, 2, 3]"
 
@@ -463,31 +510,118 @@ func TestMapLiterals(t *testing.T) {
 		t.Fatalf("Eval did not return a Map. Got %T (%+v)", evaluated, evaluated)
 	}
 
-	expected := map[object.MapKey]int64{
-		(&object.String{ Value: "one" }).MapKey(): 1,
-		(&object.String{ Value: "two" }).MapKey(): 2,
-		(&object.String{ Value: "three" }).MapKey(): 3,
-		(&object.Integer{ Value: 4 }).MapKey(): 4,
-		TRUE.MapKey(): 5,
-		FALSE.MapKey(): 6,
+	//	the pairs keep the order they were written in
+	expected := []struct{
+		key object.Object
+		value int64
+	}{
+		{&object.String{ Value: "one" }, 1},
+		{&object.String{ Value: "two" }, 2},
+		{&object.String{ Value: "three" }, 3},
+		{&object.Integer{ Value: 4 }, 4},
+		{TRUE, 5},
+		{FALSE, 6},
 	}
 
-	if len(result.Pairs) != len(expected) {
+	if result.Len() != len(expected) {
 		t.Fatalf(
 			"Map has wrong number of pairs. Got %d, expected %d",
-			len(result.Pairs),
+			result.Len(),
 			len(expected),
 		)
 	}
 
-	for expectedKey, expectedValue := range expected {
-		pair, ok := result.Pairs[expectedKey]
+	for i, pair := range result.Pairs() {
+		if !object.Equal(pair.Key, expected[i].key) {
+			t.Errorf("Wrong key at position %d. Expected %s, got %s", i, expected[i].key.Inspect(), pair.Key.Inspect())
+		}
+
+		testIntegerObject(t, pair.Value, expected[i].value)
+	}
+
+	for _, tt := range expected {
+		value, ok := result.Get(tt.key)
 
 		if !ok {
 			t.Errorf("No pair found for given key in pairs")
+			continue
+		}
+
+		testIntegerObject(t, value, tt.value)
+	}
+}
+
+func TestMapInspectOrder(t *testing.T) {
+	input := `{"b": 2, "a": 1, "c": [1, 2], 10: "ten"}`
+	expected := `{b: 2, a: 1, c: [1, 2], 10: ten}`
+
+	for i := 0; i < 20; i++ {
+		evaluated := testEval(input)
+
+		if evaluated.Inspect() != expected {
+			t.Fatalf("Wrong map output. Expected %q, got %q", expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestCompositeMapKeys(t *testing.T) {
+	tests := []struct{
+		input string
+		expected interface{}
+	}{
+		{`{[1, 2]: "a"}[[1, 2]]`, "a"},
+		{`{[1, 2]: "a"}[[2, 1]]`, nil},
+		{`{["x", [true, 3]]: "nested"}[["x", [true, 3]]]`, "nested"},
+		{`var array key = [1]; var map m = {key: "one"}; push(key, 2); m[[1]];`, "one"},
+		{`{[func(x) { x }]: 1}`, "Unusable as a map key: ARRAY"},
+		{`{"a": 1}[[func(x) { x }]]`, "Unsupported as map key: ARRAY"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case string:
+			if errObj, ok := evaluated.(*object.Error); ok {
+				if errObj.Message != expected {
+					t.Errorf("Wrong error message. Expected %q, got %q", expected, errObj.Message)
+				}
+				continue
+			}
+
+			if evaluated.Inspect() != expected {
+				t.Errorf("Expected %q, got %q", expected, evaluated.Inspect())
+			}
+		default:
+			testNullObject(t, evaluated)
 		}
+	}
+}
+
+func TestDeepEquality(t *testing.T) {
+	tests := []struct{
+		input string
+		expected bool
+	}{
+		{"[1, 2, 3] == [1, 2, 3]", true},
+		{"[1, 2, 3] == [1, 2]", false},
+		{"[1, 2, 3] != [1, 2, 4]", true},
+		{"[[1, 2], [3]] == [[1, 2], [3]]", true},
+		{`["a", true] == ["a", false]`, false},
+		{"[] == []", true},
+		{`{"a": 1, "b": 2} == {"b": 2, "a": 1}`, true},
+		{`{"a": 1, "b": 2} == {"a": 1, "b": 3}`, false},
+		{`{"a": 1} == {"a": 1, "b": 2}`, false},
+		{`{"a": [1, {"b": 2}]} == {"a": [1, {"b": 2}]}`, true},
+		{`{"a": 1} != {"a": 1}`, false},
+		{`[1] == {"a": 1}`, false},
+		{`[9223372036854775807 + 1] == [9223372036854775807 + 1]`, true},
+		{"var array a = [1]; push(a, a); var array b = [1]; push(b, b); a == b;", true},
+		{"var array a = [1]; a == a;", true},
+	}
 
-		testIntegerObject(t, pair.Value, expectedValue)
+	for _, tt := range tests {
+		testBooleanObject(t, testEval(tt.input), tt.expected)
 	}
 }
 
@@ -577,52 +711,1538 @@ func TestReassignmentStatement(t *testing.T) {
 	}
 }
 
-func testEval(input string) object.Object {
-	l := lexer.New(input)
-	p := parser.New(l)
-	program := p.ParserProgram()
-	env := object.NewEnvironment()
+func TestErrorStackTrace(t *testing.T) {
+	input := `
+var fn down = func(n) {
+	if (n == 0) {
+		return true + 1;
+	}
+	return down(n - 1);
+};
+var fn start = func() {
+	down(2);
+};
+start();
+`
 
-	return Eval(program, env)
+	evaluated := testEval(input)
+	errObj, ok := evaluated.(*object.Error)
+
+	if !ok {
+		t.Fatalf("No error object returned. Got %T (%+v)", evaluated, evaluated)
+	}
+
+	if errObj.Line != 4 || errObj.Column != 15 {
+		t.Errorf("Wrong error position. Expected 4:15, got %d:%d", errObj.Line, errObj.Column)
+	}
+
+	expectedStack := []object.Frame{
+		{Function: "down", Line: 6, Column: 13},
+		{Function: "down", Line: 6, Column: 13},
+		{Function: "down", Line: 9, Column: 6},
+		{Function: "start", Line: 11, Column: 6},
+	}
+
+	if len(errObj.Stack) != len(expectedStack) {
+		t.Fatalf("Wrong stack size. Expected %d, got %d (%+v)", len(expectedStack), len(errObj.Stack), errObj.Stack)
+	}
+
+	for i, expected := range expectedStack {
+		if errObj.Stack[i] != expected {
+			t.Errorf("Stack[%d] wrong. Expected %+v, got %+v", i, expected, errObj.Stack[i])
+		}
+	}
 }
 
-func testIntegerObject(t *testing.T, obj object.Object, expected int64) bool {
-	result, ok := obj.(*object.Integer)
+func TestBuiltInErrorStackTrace(t *testing.T) {
+	evaluated := testEval("var array a = [];\nremoveAt(a, 3);")
+	errObj, ok := evaluated.(*object.Error)
 
 	if !ok {
-		t.Errorf("object is not an Integer, got %T", obj)
-		return false
+		t.Fatalf("No error object returned. Got %T (%+v)", evaluated, evaluated)
 	}
 
-	if result.Value != expected {
-		t.Errorf("Received result %d, expected %d", result.Value, expected)
-		return false
+	if len(errObj.Stack) != 1 || errObj.Stack[0].Function != "removeAt" {
+		t.Fatalf("Expected a single removeAt frame, got %+v", errObj.Stack)
 	}
 
-	return true
+	if errObj.Line != 2 || errObj.Column != 9 {
+		t.Errorf("Wrong error position. Expected 2:9, got %d:%d", errObj.Line, errObj.Column)
+	}
 }
 
-func testBooleanObject(t *testing.T, obj object.Object, expected bool) bool {
-	result, ok := obj.(*object.Boolean)
+func TestTryCatchStatement(t *testing.T) {
+	tests := []struct{
+		input string
+		expected interface{}
+	}{
+		{
+			`var array a = []; try { removeAt(a, 1); 1; } catch (e) { 2; }`,
+			2,
+		},
+		{
+			`try { 10 / 0; } catch (e) { e["kind"]; }`,
+			"RuntimeError",
+		},
+		{
+			`try { 10 / 0; } catch (e) { e["message"]; }`,
+			"Error: division by zero not supported",
+		},
+		{
+			`try { throw "boom"; } catch (e) { e["message"]; }`,
+			"boom",
+		},
+		{
+			`try { throw "boom"; } catch (e) { e["kind"]; }`,
+			"Error",
+		},
+		{
+			`try { throw error("bad value", "ValueError"); } catch (e) { e["kind"]; }`,
+			"ValueError",
+		},
+		{
+			`try { 1; } catch (e) { 2; }`,
+			1,
+		},
+		{
+			`var int x = 0; try { throw "boom"; } catch (e) { x = 1; } finally { x = x + 10; }; x;`,
+			11,
+		},
+		{
+			`var int x = 0; try { x = 1; } finally { x = x + 10; }; x;`,
+			11,
+		},
+		{
+			`var int e = 5; try { throw "boom"; } catch (e) { e["message"]; }`,
+			"boom",
+		},
+		{
+			`var int e = 5; try { throw "boom"; } catch (e) { 1; }; e;`,
+			5,
+		},
+		{
+			`try { try { throw "boom"; } catch (e) { 1; }; e; } catch (outer) { outer["message"]; }`,
+			"Identifier not found: e",
+		},
+		{
+			`
+			var array cleaned = [];
+			var fn f = func() {
+				try {
+					return 1;
+				} finally {
+					push(cleaned, true);
+				}
+				return 2;
+			};
+			f() + length(cleaned);
+			`,
+			2,
+		},
+		{
+			`
+			var int i = 0;
+			try {
+				for (i < 10) {
+					if (i == 3) { throw "stop"; }
+					i = i + 1;
+				}
+			} catch (e) {
+				i = i * 100;
+			}
+			i;
+			`,
+			300,
+		},
+		{
+			`
+			try {
+				try { throw "inner"; } catch (e) { throw e; }
+			} catch (outer) {
+				outer["message"];
+			}
+			`,
+			"inner",
+		},
+		{
+			`var fn f = func() { throw "deep"; }; try { f(); } catch (e) { length(e["stack"]); }`,
+			1,
+		},
+	}
 
-	if !ok {
-		t.Errorf("obj not of type Boolean, got %T (%+v)", obj, obj)
-		return false
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			str, ok := evaluated.(*object.String)
+			if !ok {
+				t.Errorf("object is not a String, got %T (%+v)", evaluated, evaluated)
+				continue
+			}
+
+			if str.Value != expected {
+				t.Errorf("Wrong string. Expected %q, got %q", expected, str.Value)
+			}
+		}
 	}
+}
 
-	if result.Value != expected {
-		t.Errorf("result.Value was %t, expected %t", result.Value, expected)
-		return false
+func TestUncaughtThrow(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`throw "boom"; 5;`, "Error: boom"},
+		{`throw error("missing", "NotFound");`, "NotFound: missing"},
+		{`try { 1; } finally { throw "from finally"; }`, "Error: from finally"},
+		{`try { throw "first"; } catch (e) { throw "second"; }`, "Error: second"},
 	}
 
-	return true
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		errObj, ok := evaluated.(*object.Error)
+
+		if !ok {
+			t.Errorf("No error object returned. Got %T (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Inspect() != tt.expected {
+			t.Errorf("Wrong error. Expected %q, got %q", tt.expected, errObj.Inspect())
+		}
+	}
 }
 
-func testNullObject(t *testing.T, obj object.Object) bool {
-	if obj != NULL {
-		t.Errorf("object is not NULL, got %T (%+v)", obj, obj)
-		return false
+func TestErrorValues(t *testing.T) {
+	tests := []struct{
+		input string
+		expected interface{}
+	}{
+		{`isError(error("bad"))`, true},
+		{`isError(5)`, false},
+		{`isError({"message": "not an error"})`, false},
+		{`isError({"message": "hi", "kind": "k", "stack": []})`, false},
+		{`isError(jsonParse("{\"message\": \"hi\", \"kind\": \"k\", \"stack\": []}"))`, false},
+		{`errorMessage(error("bad"))`, "bad"},
+		{`errorMessage(5)`, "Argument to `errorMessage` must be an error, got INTEGER"},
+		{
+			`
+			var array a = [];
+			var fn removeFirst = func(arr) {
+				removeAt(arr, 0)?;
+				return "removed";
+			};
+			var map result = removeFirst(a);
+			isError(result);
+			`,
+			true,
+		},
+		{
+			`
+			var fn removeFirst = func(arr) {
+				removeAt(arr, 0)?;
+				return "removed";
+			};
+			removeFirst([1, 2]);
+			`,
+			"removed",
+		},
+		{
+			`
+			var fn check = func(x) {
+				if (x < 0) {
+					return error("negative");
+				}
+				return x;
+			};
+			var fn twice = func(x) {
+				var int checked = check(x)?;
+				return checked * 2;
+			};
+			errorMessage(twice(-1));
+			`,
+			"negative",
+		},
+		{
+			`
+			var fn check = func(x) {
+				if (x < 0) {
+					return error("negative");
+				}
+				return x;
+			};
+			var fn twice = func(x) {
+				return check(x)? * 2;
+			};
+			twice(21);
+			`,
+			42,
+		},
+		{
+			`
+			var fn parse = func() { 1 + true; };
+			var fn wrapper = func() { parse()?; 10; };
+			errorMessage(wrapper());
+			`,
+			"Type mismatch: INTEGER + BOOLEAN",
+		},
+		{
+			`
+			var fn read = func() {
+				var map data = {"message": "hi", "kind": "k", "stack": []}?;
+				return data["message"];
+			};
+			read();
+			`,
+			"hi",
+		},
+		//	outside of a function ? has nothing to return from, so the program fails
+		{`var int x = int("x")?; "after";`, `Cannot convert "x" to an integer`},
+		{`error("bad")?; "after";`, "bad"},
+		{`try { error("bad", "ValueError")?; } catch (e) { e["kind"] + ": " + e["message"]; }`, "ValueError: bad"},
+		{
+			`
+			var fn check = func() { return int("x")?; };
+			var map result = check();
+			errorMessage(result);
+			`,
+			`Cannot convert "x" to an integer`,
+		},
 	}
 
-	return true
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			if errObj, ok := evaluated.(*object.Error); ok {
+				if errObj.Message != expected {
+					t.Errorf("Wrong error message. Expected %q, got %q", expected, errObj.Message)
+				}
+				continue
+			}
+
+			str, ok := evaluated.(*object.String)
+			if !ok {
+				t.Errorf("object is not a String, got %T (%+v)", evaluated, evaluated)
+				continue
+			}
+
+			if str.Value != expected {
+				t.Errorf("Wrong string. Expected %q, got %q", expected, str.Value)
+			}
+		}
+	}
+}
+
+func TestIntegerOverflowPromotion(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{"9223372036854775807 + 1", "9223372036854775808"},
+		{"-9223372036854775807 - 2", "-9223372036854775809"},
+		{"0 - 9223372036854775807 - 1 - 1", "-9223372036854775809"},
+		{"4294967296 * 4294967296", "18446744073709551616"},
+		{"4294967296 * -4294967296", "-18446744073709551616"},
+		{"-(0 - 9223372036854775807 - 1)", "9223372036854775808"},
+		{"(0 - 9223372036854775807 - 1) / -1", "9223372036854775808"},
+		{"(9223372036854775807 + 1) * (9223372036854775807 + 1)", "85070591730234615865843651857942052864"},
+		{"(9223372036854775807 + 10) / 10", "922337203685477581"},
+		{"-(9223372036854775807 + 1)", "-9223372036854775808"},
+		{"9223372036854775808", "9223372036854775808"},
+		{"123456789012345678901234567890 + 1", "123456789012345678901234567891"},
+		{"var int x = 99999999999999999999; x = x * 10; x;", "999999999999999999990"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: expected %s, got %s (%T)", tt.input, tt.expected, evaluated.Inspect(), evaluated)
+		}
+	}
+}
+
+func TestBigIntNormalization(t *testing.T) {
+	tests := []struct{
+		input string
+		expected int64
+	}{
+		{"9223372036854775807 + 1 - 1", 9223372036854775807},
+		{"(9223372036854775807 + 1) / 2", 4611686018427387904},
+		{"-(9223372036854775807 + 1)", -9223372036854775807 - 1},
+		{"var int x = 9223372036854775807; x = x + 1; x = x - 10; x;", 9223372036854775798},
+		{"-9223372036854775808", -9223372036854775807 - 1},
+		{"9223372036854775808 - 1", 9223372036854775807},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestBigIntComparisons(t *testing.T) {
+	tests := []struct{
+		input string
+		expected bool
+	}{
+		{"9223372036854775807 + 1 > 9223372036854775807", true},
+		{"9223372036854775807 + 1 < 9223372036854775807", false},
+		{"9223372036854775807 + 1 == 9223372036854775807 + 1", true},
+		{"9223372036854775807 + 1 != 9223372036854775807 + 2", true},
+		{"-(9223372036854775807 + 2) <= 0", true},
+		{"9223372036854775807 * 2 >= 9223372036854775807 + 9223372036854775807", true},
+	}
+
+	for _, tt := range tests {
+		testBooleanObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestBigIntMapKeys(t *testing.T) {
+	tests := []struct{
+		input string
+		expected int64
+	}{
+		{`{9223372036854775807 + 1 - 1: 5}[9223372036854775807]`, 5},
+		{`{9223372036854775807 + 1: 6}[9223372036854775807 + 1]`, 6},
+		{`{9223372036854775807 * 3: 7}[9223372036854775807 + 9223372036854775807 + 9223372036854775807]`, 7},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEval(tt.input), tt.expected)
+	}
+
+	testNullObject(t, testEval(`{9223372036854775807 + 1: 1}[-(9223372036854775807 + 1)]`))
+}
+
+func testEval(input string) object.Object {
+	l := lexer.New(input)
+	p := parser.New(l)
+	program := p.ParserProgram()
+	env := object.NewEnvironment()
+
+	return Eval(program, env)
+}
+
+func testEvalWith(interpreter *Interpreter, input string) object.Object {
+	l := lexer.New(input)
+	p := parser.New(l)
+	program := p.ParserProgram()
+
+	return interpreter.Eval(program, object.NewEnvironment())
+}
+
+func testIntegerObject(t *testing.T, obj object.Object, expected int64) bool {
+	result, ok := obj.(*object.Integer)
+
+	if !ok {
+		t.Errorf("object is not an Integer, got %T", obj)
+		return false
+	}
+
+	if result.Value != expected {
+		t.Errorf("Received result %d, expected %d", result.Value, expected)
+		return false
+	}
+
+	return true
+}
+
+func testBooleanObject(t *testing.T, obj object.Object, expected bool) bool {
+	result, ok := obj.(*object.Boolean)
+
+	if !ok {
+		t.Errorf("obj not of type Boolean, got %T (%+v)", obj, obj)
+		return false
+	}
+
+	if result.Value != expected {
+		t.Errorf("result.Value was %t, expected %t", result.Value, expected)
+		return false
+	}
+
+	return true
+}
+
+func testNullObject(t *testing.T, obj object.Object) bool {
+	if obj != NULL {
+		t.Errorf("object is not NULL, got %T (%+v)", obj, obj)
+		return false
+	}
+
+	return true
+}
+
+func TestHigherOrderBuiltInFunctions(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`map([1, 2, 3], func(x) { x * 2 })`, "[2, 4, 6]"},
+		{`map([], func(x) { x })`, "[]"},
+		{`map(["a", "bc"], length)`, "[1, 2]"},
+		{`filter([1, 2, 3, 4], func(x) { x > 2 })`, "[3, 4]"},
+		{`reduce([1, 2, 3, 4], func(acc, x) { acc + x }, 0)`, "10"},
+		{`reduce([], func(acc, x) { acc + x }, 5)`, "5"},
+		{`var array seen = []; forEach([1, 2, 3], func(x) { push(seen, x * 2); }); seen;`, "[2, 4, 6]"},
+		{`find([1, 5, 10], func(x) { x > 3 })`, "5"},
+		{`find([1, 2], func(x) { x > 3 })`, "null"},
+		{`findIndex([1, 5, 10], func(x) { x > 3 })`, "1"},
+		{`findIndex([1, 2], func(x) { x > 3 })`, "-1"},
+		{`any([1, 2, 3], func(x) { x == 2 })`, "true"},
+		{`any([], func(x) { true })`, "false"},
+		{`all([1, 2, 3], func(x) { x > 0 })`, "true"},
+		{`all([1, 2, 3], func(x) { x > 1 })`, "false"},
+		{`sort([3, 1, 2])`, "[1, 2, 3]"},
+		{`sort(["b", "c", "a"])`, "[a, b, c]"},
+		{`sort([true, false])`, "[false, true]"},
+		{`sort([2, "a", 1])`, "[1, 2, a]"},
+		{`var array a = [3, 1, 2]; sort(a); a;`, "[3, 1, 2]"},
+		{`sort([3, 1, 2], func(a, b) { b - a })`, "[3, 2, 1]"},
+		{`sort([[2, "x"], [1, "y"], [2, "z"]], func(a, b) { a[0] - b[0] })`, "[[1, y], [2, x], [2, z]]"},
+		{`map([1], func(x, y) { x })`, "Wrong number of arguments. Got 1, expected 2"},
+		{`map(1, func(x) { x })`, "First argument to `map` must be an Array, got INTEGER"},
+		{`filter([1], 2)`, "Second argument to `filter` must be a Function, got INTEGER"},
+		{`map([1, "a"], func(x) { -x })`, "Unknown operator: -STRING"},
+		{`sort([1, 2], func(a, b) { true })`, "Comparison function passed to `sort` must return an Integer, got BOOLEAN"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		if errObj, ok := evaluated.(*object.Error); ok {
+			if errObj.Message != tt.expected {
+				t.Errorf("%s: wrong error message. Expected %q, got %q", tt.input, tt.expected, errObj.Message)
+			}
+			continue
+		}
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: expected %s, got %s", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestCallbackErrorStackTrace(t *testing.T) {
+	input := `var fn fail = func(x) { x + true };
+map([1], fail);`
+
+	evaluated := testEval(input)
+
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("expected an error, got %T (%+v)", evaluated, evaluated)
+	}
+
+	expected := "Error: Type mismatch: INTEGER + BOOLEAN\n" +
+		"    at line 1, column 27\n" +
+		"    in map, called at line 2, column 4"
+
+	if errObj.Traceback() != expected {
+		t.Errorf("wrong traceback. Expected\n%s\ngot\n%s", expected, errObj.Traceback())
+	}
+}
+
+func TestIndexAndSliceExpressions(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`[1, 2, 3][-1]`, "3"},
+		{`[1, 2, 3][-3]`, "1"},
+		{`[1, 2, 3][-4]`, "null"},
+		{`[1, 2, 3][3]`, "null"},
+		{`[1, 2, 3, 4][1:3]`, "[2, 3]"},
+		{`[1, 2, 3, 4][:2]`, "[1, 2]"},
+		{`[1, 2, 3, 4][2:]`, "[3, 4]"},
+		{`[1, 2, 3, 4][:]`, "[1, 2, 3, 4]"},
+		{`[1, 2, 3, 4][-2:]`, "[3, 4]"},
+		{`[1, 2, 3, 4][1:-1]`, "[2, 3]"},
+		{`[1, 2, 3, 4][3:1]`, "[]"},
+		{`[1, 2, 3, 4][2:10]`, "[3, 4]"},
+		{`var array a = [1, 2, 3]; var array b = a[:]; push(b, 4); a;`, "[1, 2, 3]"},
+		{`"hello"[0]`, "h"},
+		{`"hello"[-1]`, "o"},
+		{`"hello"[5]`, "null"},
+		{`"hello"[1:3]`, "el"},
+		{`"hello"[:2]`, "he"},
+		{`"hello"[3:]`, "lo"},
+		{`"héllo"[1]`, "é"},
+		{`length("héllo")`, "5"},
+		{`var string s = "hello"; var string first = s[0]; first;`, "h"},
+		{`[1, 2][1:"a"]`, "Slice bounds must be Integers, got STRING"},
+		{`5[1:2]`, "Slice operator not supported: INTEGER"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		if errObj, ok := evaluated.(*object.Error); ok {
+			if errObj.Message != tt.expected {
+				t.Errorf("%s: wrong error message. Expected %q, got %q", tt.input, tt.expected, errObj.Message)
+			}
+			continue
+		}
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: expected %s, got %s", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestStrictIndexing(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`[1, 2, 3][3]`, "Index out of range: 3 (length 3)"},
+		{`[1, 2, 3][-4]`, "Index out of range: -4 (length 3)"},
+		{`"abc"[10]`, "Index out of range: 10 (length 3)"},
+	}
+
+	for _, tt := range tests {
+		interpreter := New()
+		interpreter.StrictIndexing = true
+		evaluated := testEvalWith(interpreter, tt.input)
+
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("%s: expected an error, got %T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Message != tt.expected {
+			t.Errorf("%s: wrong error message. Expected %q, got %q", tt.input, tt.expected, errObj.Message)
+		}
+	}
+}
+
+func TestStringBuiltInFunctions(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`split("a,b,c", ",")`, "[a, b, c]"},
+		{`split("abc", "")`, "[a, b, c]"},
+		{`join(["a", "b", "c"], "-")`, "a-b-c"},
+		{`join([], "-")`, ""},
+		{`trim("  hi  ")`, "hi"},
+		{`upper("Hi")`, "HI"},
+		{`lower("Hi")`, "hi"},
+		{`replace("a-b-c", "-", "+")`, "a+b+c"},
+		{`contains("hello", "ell")`, "true"},
+		{`contains("hello", "xyz")`, "false"},
+		{`startsWith("hello", "he")`, "true"},
+		{`endsWith("hello", "he")`, "false"},
+		{`indexOf("hello", "l")`, "2"},
+		{`indexOf("héllo", "l")`, "2"},
+		{`indexOf("hello", "z")`, "-1"},
+		{`repeat("ab", 3)`, "ababab"},
+		{`repeat("ab", 0)`, ""},
+		{`padLeft("7", 3, "0")`, "007"},
+		{`padRight("ab", 4)`, "ab  "},
+		{`padLeft("abcd", 2)`, "abcd"},
+		{`chars("héy")`, "[h, é, y]"},
+		{`format("%s is %d", "x", 5)`, "x is 5"},
+		{`format("%v %t %5.1s", [1, 2], true, "abc")`, "[1, 2] true     a"},
+		{`format("%d", 9223372036854775807 + 1)`, "9223372036854775808"},
+		{`format("%5.1f", 2.5)`, "  2.5"},
+		{`format("%.2f and %v", 3.14159, 0.5)`, "3.14 and 0.5"},
+		{`"apple" < "banana"`, "true"},
+		{`"apple" > "banana"`, "false"},
+		{`"a" <= "a"`, "true"},
+		{`"b" >= "c"`, "false"},
+		{`sort(split("c b a", " "))`, "[a, b, c]"},
+		{`split("a", 1)`, "Second argument to `split` must be a String, got INTEGER"},
+		{`upper()`, "Wrong number of arguments. Got 0, expected 1"},
+		{`join([1], ",")`, "Elements passed to `join` must be Strings, got INTEGER"},
+		{`repeat("a", -1)`, "Cannot repeat a string a negative number of times: -1"},
+		{`repeat("ab", 9223372036854775807)`, "Cannot repeat a string 9223372036854775807 times, the result would be too long"},
+		{`repeat("", 9223372036854775807)`, ""},
+		{`padLeft("a", 9223372036854775807)`, "Width passed to `padLeft` is too big: 9223372036854775807"},
+		{`padRight("a", 1000000000, "é")`, "Width passed to `padRight` is too big: 1000000000"},
+		{`padLeft("a", 3, "ab")`, "Third argument to `padLeft` must be a single character"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		if errObj, ok := evaluated.(*object.Error); ok {
+			if errObj.Message != tt.expected {
+				t.Errorf("%s: wrong error message. Expected %q, got %q", tt.input, tt.expected, errObj.Message)
+			}
+			continue
+		}
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestConversionBuiltInFunctions(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`int("42")`, "42"},
+		{`int(" -7 ")`, "-7"},
+		{`int("99999999999999999999")`, "99999999999999999999"},
+		{`int(true)`, "1"},
+		{`int(double("3.9"))`, "3"},
+		{`int(double("-3.9"))`, "-3"},
+		{`int(5)`, "5"},
+		{`double("1.5")`, "1.5"},
+		{`double(2)`, "2.0"},
+		{`string(42)`, "42"},
+		{`string([1, "a"])`, "[1, a]"},
+		{`string(42) + "!"`, "42!"},
+		{`bool("true")`, "true"},
+		{`bool(0)`, "false"},
+		{`bool([])`, "false"},
+		{`bool({"a": 1})`, "true"},
+		{`"n=" + 5`, "n=5"},
+		{`5 + " apples"`, "5 apples"},
+		{`"list: " + [1, 2]`, "list: [1, 2]"},
+		{`typeOf(1)`, "INTEGER"},
+		{`typeOf("a")`, "STRING"},
+		{`typeOf(double("1"))`, "DOUBLE"},
+		{`typeOf({})`, "MAP"},
+		{`typeOf(length)`, "BUILTIN"},
+		{`isInt(1)`, "true"},
+		{`isInt(9223372036854775807 + 1)`, "true"},
+		{`isInt("1")`, "false"},
+		{`isString("1")`, "true"},
+		{`isDouble(double(1))`, "true"},
+		{`isBool(false)`, "true"},
+		{`isArray([])`, "true"},
+		{`isMap({})`, "true"},
+		{`isFunction(func(x) { x })`, "true"},
+		{`isFunction(length)`, "true"},
+		{`isNull(find([], func(x) { true }))`, "true"},
+		{`int("abc")`, `Cannot convert "abc" to an integer`},
+		{`int("1.5")`, `Cannot convert "1.5" to an integer`},
+		{`double("x")`, `Cannot convert "x" to a double`},
+		{`bool("yes")`, `Cannot convert "yes" to a boolean`},
+		{`int([1])`, "Cannot convert ARRAY to an integer"},
+		{`int(1, 2)`, "Wrong number of arguments. Got 2, expected 1"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		if errObj, ok := evaluated.(*object.Error); ok {
+			if errObj.Message != tt.expected {
+				t.Errorf("%s: wrong error message. Expected %q, got %q", tt.input, tt.expected, errObj.Message)
+			}
+			continue
+		}
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestDoubleExpressions(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`1.5`, "1.5"},
+		{`-2.5`, "-2.5"},
+		{`1.5 + 1.5`, "3.0"},
+		{`1 + 0.5`, "1.5"},
+		{`0.5 * 4`, "2.0"},
+		{`7 / 2`, "3"},
+		{`7 / 2.0`, "3.5"},
+		{`1.0 / 0`, "Error: division by zero not supported"},
+		{`1 == 1.0`, "true"},
+		{`2.5 > 2`, "true"},
+		{`[1, 2] == [1.0, 2.0]`, "true"},
+		{`{1: "one"}[1.0]`, "one"},
+		{`(9223372036854775807 + 1) * 0.5`, "4.611686018427388e+18"},
+		{`sort([2.5, 1, 3])`, "[1, 2.5, 3]"},
+		{`"x=" + 0.25`, "x=0.25"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		if errObj, ok := evaluated.(*object.Error); ok {
+			if errObj.Message != tt.expected {
+				t.Errorf("%s: wrong error message. Expected %q, got %q", tt.input, tt.expected, errObj.Message)
+			}
+			continue
+		}
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestMathBuiltInFunctions(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`abs(-5)`, "5"},
+		{`abs(-2.5)`, "2.5"},
+		{`abs(-9223372036854775807 - 1)`, "9223372036854775808"},
+		{`min(3, 1, 2)`, "1"},
+		{`min([3, 1.5, 2])`, "1.5"},
+		{`max(3, 1, 2)`, "3"},
+		{`max([1])`, "1"},
+		{`clamp(15, 0, 10)`, "10"},
+		{`clamp(-1, 0, 10)`, "0"},
+		{`clamp(5, 0, 10)`, "5"},
+		{`sqrt(16)`, "4.0"},
+		{`floor(2.7)`, "2"},
+		{`floor(-2.5)`, "-3"},
+		{`ceil(2.1)`, "3"},
+		{`round(2.5)`, "3"},
+		{`round(7)`, "7"},
+		{`pow(2, 10)`, "1024"},
+		{`pow(2, 64)`, "18446744073709551616"},
+		{`pow(2, -1)`, "0.5"},
+		{`pow(4, 0.5)`, "2.0"},
+		{`pow(1, 100000000000)`, "1"},
+		{`pow(-1, 100000000001)`, "-1"},
+		{`length(string(pow(2, 100000)))`, "30103"},
+		{`log(E)`, "1.0"},
+		{`sin(0)`, "0.0"},
+		{`cos(0)`, "1.0"},
+		{`round(PI * 100)`, "314"},
+		{`sum([1, 2, 3])`, "6"},
+		{`sum([1, 2.5])`, "3.5"},
+		{`sum([])`, "0"},
+		{`sum([9223372036854775807, 1])`, "9223372036854775808"},
+		{`avg([1, 2])`, "1.5"},
+		{`min()`, "`min` needs at least one number"},
+		{`max(1, "a")`, "Arguments passed to `max` must be numbers, got STRING"},
+		{`clamp(1, 10, 0)`, "The lower bound passed to `clamp` is bigger than the upper bound"},
+		{`sqrt(-1)`, "Cannot take the square root of a negative number: -1"},
+		{`log(0)`, "Cannot take the logarithm of a number that is not positive: 0"},
+		{`abs("a")`, "First argument to `abs` must be a number, got STRING"},
+		{`pow(3, 100000000000)`, "Exponent passed to `pow` is too big: 100000000000"},
+		{`pow(2, 9223372036854775807 + 1)`, "Exponent passed to `pow` is too big: 9223372036854775808"},
+		{`avg([])`, "Cannot take the average of an empty array"},
+		{`sum(["a"])`, "Elements passed to `sum` must be numbers, got STRING"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		if errObj, ok := evaluated.(*object.Error); ok {
+			if errObj.Message != tt.expected {
+				t.Errorf("%s: wrong error message. Expected %q, got %q", tt.input, tt.expected, errObj.Message)
+			}
+			continue
+		}
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestRandomBuiltInFunctions(t *testing.T) {
+	input := `seed(42); [random(), randInt(1, 100), choice(["a", "b", "c"]), shuffle([1, 2, 3, 4, 5])];`
+
+	first := testEval(input).Inspect()
+	second := testEval(input).Inspect()
+
+	if first != second {
+		t.Errorf("the same seed gave different values: %s and %s", first, second)
+	}
+
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`all(map(range(1, 100), func(x) { randInt(1, 3) }), func(n) { (n >= 1) && (n <= 3) })`, "true"},
+		{`var double r = random(); (r >= 0) && (r < 1);`, "true"},
+		{`randInt(5, 5)`, "5"},
+		{`choice([7])`, "7"},
+		{`sort(shuffle([3, 1, 2]))`, "[1, 2, 3]"},
+		{`var array a = [1, 2, 3]; seed(1); shuffle(a); a;`, "[1, 2, 3]"},
+		{`randInt(5, 1)`, "The lower bound passed to `randInt` is bigger than the upper bound"},
+		{`choice([])`, "Cannot choose from an empty array"},
+		{`seed("a")`, "Argument to `seed` must be an Integer, got STRING"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		if errObj, ok := evaluated.(*object.Error); ok {
+			if errObj.Message != tt.expected {
+				t.Errorf("%s: wrong error message. Expected %q, got %q", tt.input, tt.expected, errObj.Message)
+			}
+			continue
+		}
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestRandomStateIsPerInterpreter(t *testing.T) {
+	first, second := New(), New()
+	first.Seed(7)
+	second.Seed(7)
+
+	for i := 0; i < 3; i++ {
+		expected := testEvalWith(first, `randInt(0, 1000000)`).Inspect()
+		//	other interpreters drawing and seeding do not move the generator of the second one
+		testEvalWith(New(), `seed(1); random();`)
+		got := testEvalWith(second, `randInt(0, 1000000)`).Inspect()
+
+		if got != expected {
+			t.Fatalf("interpreters with the same seed gave %s and %s", expected, got)
+		}
+	}
+}
+
+func TestFileSystemBuiltInFunctions(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{"writeFile(\"DIR/a.txt\", \"one\n\"); appendFile(\"DIR/a.txt\", \"two\n\"); readFile(\"DIR/a.txt\");", "one\ntwo\n"},
+		{`readLines("DIR/a.txt")`, "[one, two]"},
+		{`exists("DIR/a.txt")`, "true"},
+		{`exists("DIR/missing.txt")`, "false"},
+		{`mkdir("DIR/sub/inner"); exists("DIR/sub/inner");`, "true"},
+		{`listDir("DIR")`, "[a.txt, sub]"},
+		{`remove("DIR/a.txt"); exists("DIR/a.txt");`, "false"},
+		{`writeFile("DIR/empty.txt", ""); readLines("DIR/empty.txt");`, "[]"},
+		{`readFile("DIR/missing.txt")`, `Could not read "DIR/missing.txt": no such file or directory`},
+		{`remove("DIR/sub")`, `Could not remove "DIR/sub": directory not empty`},
+		{`writeFile("DIR/a.txt", 5)`, "Second argument to `writeFile` must be a String, got INTEGER"},
+	}
+
+	interpreter := New()
+	interpreter.AllowFileSystem = true
+
+	for _, tt := range tests {
+		input := strings.ReplaceAll(tt.input, "DIR", dir)
+		expected := strings.ReplaceAll(tt.expected, "DIR", dir)
+		evaluated := testEvalWith(interpreter, input)
+
+		if errObj, ok := evaluated.(*object.Error); ok {
+			if errObj.Message != expected {
+				t.Errorf("%s: wrong error message. Expected %q, got %q", input, expected, errObj.Message)
+			}
+			continue
+		}
+
+		if evaluated.Inspect() != expected {
+			t.Errorf("%s: expected %q, got %q", input, expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestFileSystemAccessDisabled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.txt")
+	evaluated := testEval(`writeFile("` + path + `", "data")`)
+
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("expected an error, got %T (%+v)", evaluated, evaluated)
+	}
+
+	if errObj.Message != "File system access is disabled, `writeFile` cannot be used" {
+		t.Errorf("wrong error message, got %q", errObj.Message)
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		t.Errorf("the file was written with file system access disabled")
+	}
+}
+
+func TestExitStopsEvaluation(t *testing.T) {
+	tests := []struct{
+		input string
+		expected int64
+	}{
+		{`exit(3); 5;`, 3},
+		{`exit(); 5;`, 0},
+		{`var fn stop = func() { exit(2); 10 }; stop(); 5;`, 2},
+		{`forEach([1, 2, 3], func(x) { if (x == 2) { exit(x); } }); 5;`, 2},
+		{`try { exit(4); } catch (e) { 1 }; 5;`, 4},
+		{`var array a = map([1, 2], func(x) { exit(7) }); 5;`, 7},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		exit, ok := evaluated.(*object.Exit)
+		if !ok {
+			t.Errorf("%s: expected an exit, got %T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+
+		if exit.Code != tt.expected {
+			t.Errorf("%s: expected exit code %d, got %d", tt.input, tt.expected, exit.Code)
+		}
+	}
+}
+
+func TestScriptInputs(t *testing.T) {
+	t.Setenv("SIMPL_TEST_VARIABLE", "value")
+
+	interpreter := New()
+	interpreter.Args = []string{"one", "two"}
+	interpreter.Stdin = strings.NewReader("first line\nsecond line\nrest\nof it")
+
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`args()`, "[one, two]"},
+		{`const string first = input(); first;`, "first line"},
+		{`input("")`, "second line"},
+		{`readStdin()`, "rest\nof it"},
+		{`input()`, "null"},
+		{`env("SIMPL_TEST_VARIABLE")`, "value"},
+		{`env("SIMPL_TEST_MISSING_VARIABLE")`, "null"},
+		{`exit("1")`, "Argument to `exit` must be an Integer, got STRING"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEvalWith(interpreter, tt.input)
+
+		if errObj, ok := evaluated.(*object.Error); ok {
+			if errObj.Message != tt.expected {
+				t.Errorf("%s: wrong error message. Expected %q, got %q", tt.input, tt.expected, errObj.Message)
+			}
+			continue
+		}
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestOutputBuiltInFunctions(t *testing.T) {
+	tests := []struct{
+		input string
+		expectedOut string
+		expectedErrOut string
+	}{
+		{`print(1, "a")`, "1\na\n", ""},
+		{`println(1, "a", [2])`, "1 a [2]\n", ""},
+		{`println()`, "\n", ""},
+		{`printf("%d-%s", 5, "x"); printf("!");`, "5-x!", ""},
+		{`printf("%.2f|%d\n", 3.14159, 2);`, "3.14|2\n", ""},
+		{`eprint("oops")`, "", "oops\n"},
+		{`input("name: ")`, "name: ", ""},
+		{`var map m = {"a": 1}; set(m, "self", m); print(m);`, "{a: 1, self: {...}}\n", ""},
+		{`var array a = [1]; push(a, a); println(a, format("%v", a));`, "[1, [...]] [1, [...]]\n", ""},
+	}
+
+	for _, tt := range tests {
+		var out, errOut bytes.Buffer
+
+		interpreter := New()
+		interpreter.Out = &out
+		interpreter.ErrOut = &errOut
+		interpreter.Stdin = strings.NewReader("")
+
+		testEvalWith(interpreter, tt.input)
+
+		if out.String() != tt.expectedOut {
+			t.Errorf("%s: expected output %q, got %q", tt.input, tt.expectedOut, out.String())
+		}
+
+		if errOut.String() != tt.expectedErrOut {
+			t.Errorf("%s: expected error output %q, got %q", tt.input, tt.expectedErrOut, errOut.String())
+		}
+	}
+}
+
+func TestJSONBuiltInFunctions(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`jsonParse("{\"b\": 1, \"a\": [true, null, 2.5, \"x\"]}")`, "{b: 1, a: [true, null, 2.5, x]}"},
+		{`typeOf(jsonParse("1"))`, "INTEGER"},
+		{`typeOf(jsonParse("1.0"))`, "DOUBLE"},
+		{`typeOf(jsonParse("1e3"))`, "DOUBLE"},
+		{`jsonParse("123456789012345678901234567890")`, "123456789012345678901234567890"},
+		{`jsonParse("{\"a\": 1, \"a\": 2}")`, "{a: 2}"},
+		{`jsonParse("[]")`, "[]"},
+		{`jsonStringify({"b": 1, "a": [true, 2.5, "x"], "c": {}})`, `{"b":1,"a":[true,2.5,"x"],"c":{}}`},
+		{`jsonStringify("say \"hi\" <b>")`, `"say \"hi\" <b>"`},
+		{`jsonStringify({1: 2, true: false})`, `{"1":2,"true":false}`},
+		{`jsonStringify(9223372036854775807 + 1)`, "9223372036854775808"},
+		{`jsonStringify(2.0)`, "2"},
+		{`jsonStringify([1, [2]], 2)`, "[\n  1,\n  [\n    2\n  ]\n]"},
+		{`jsonStringify({"a": 1}, "\t")`, "{\n\t\"a\": 1\n}"},
+		{`jsonStringify(jsonParse("{\"z\": 1, \"y\": {\"x\": [1, 2]}}"))`, `{"z":1,"y":{"x":[1,2]}}`},
+		{`jsonParse("{\"a\": ")`, "Could not parse JSON: unexpected end of the string"},
+		{`jsonParse("{\"a\" 1}")`, "Could not parse JSON: invalid character '1' after object key"},
+		{`jsonParse("")`, "Could not parse JSON: the string is empty"},
+		{`jsonParse("1 2")`, "Could not parse JSON: unexpected data after the value"},
+		{`jsonStringify({"f": func(x) { x }})`, "Cannot convert FUNCTION to JSON"},
+		{`jsonStringify([length])`, "Cannot convert BUILTIN to JSON"},
+		{`jsonStringify({[1]: 2})`, "Cannot convert a map key of type ARRAY to JSON"},
+		{`var array a = [1]; push(a, a); jsonStringify(a);`, "Cannot convert an array that contains itself to JSON"},
+		{`var map m = {}; set(m, "self", m); jsonStringify(m);`, "Cannot convert a map that contains itself to JSON"},
+		{`var array a = [1]; jsonStringify([a, a]);`, "[[1],[1]]"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		if errObj, ok := evaluated.(*object.Error); ok {
+			if errObj.Message != tt.expected {
+				t.Errorf("%s: wrong error message. Expected %q, got %q", tt.input, tt.expected, errObj.Message)
+			}
+			continue
+		}
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestCSVBuiltInFunctions(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`csvParse("a,b\n1,2\n")`, "[[a, b], [1, 2]]"},
+		{`csvParse("name,note\nana,\"says \"\"hi\"\", then, leaves\"\n")`, `[[name, note], [ana, says "hi", then, leaves]]`},
+		{`csvParse("a;b\n1;2", {"delimiter": ";"})`, "[[a, b], [1, 2]]"},
+		{`csvParse("name,age\nana,30\nbo,25", {"header": true})`, "[{name: ana, age: 30}, {name: bo, age: 25}]"},
+		{`csvParse("", {"header": true})`, "[]"},
+		{`map(csvParse("n\n1\n2", {"header": true}), func(row) { int(row["n"]) })`, "[1, 2]"},
+		{`csvStringify([["a", "b"], [1, true]])`, "a,b\n1,true\n"},
+		{`csvStringify([["x,y", "say \"hi\""]])`, "\"x,y\",\"say \"\"hi\"\"\"\n"},
+		{`csvStringify([{"name": "ana", "age": 30}, {"age": 25, "name": "bo"}])`, "name,age\nana,30\nbo,25\n"},
+		{`csvStringify([{"a": 1}, {"b": 2}], {"header": false})`, "1\n\n"},
+		{`csvStringify([[1, "", 2]], {"delimiter": "\t"})`, "1\t\t2\n"},
+		{`csvStringify([])`, ""},
+		{`csvParse(csvStringify([["a", "b,c"]]))`, "[[a, b,c]]"},
+		{`csvParse("a,b\n1")`, "Could not parse CSV: record on line 2: wrong number of fields"},
+		{`csvParse("a", {"delimiter": ";;"})`, "The delimiter option of `csvParse` must be a single character"},
+		{`csvParse("a", {"quote": "'"})`, "Unknown option passed to `csvParse`: quote"},
+		{`csvParse("a", {"header": "yes"})`, "The header option of `csvParse` must be a Boolean, got STRING"},
+		{`csvStringify([[1], {"a": 1}])`, "Rows passed to `csvStringify` must all be Arrays or all be Maps"},
+		{`csvStringify([1])`, "Rows passed to `csvStringify` must be Arrays or Maps, got INTEGER"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		if errObj, ok := evaluated.(*object.Error); ok {
+			if errObj.Message != tt.expected {
+				t.Errorf("%s: wrong error message. Expected %q, got %q", tt.input, tt.expected, errObj.Message)
+			}
+			continue
+		}
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestRegexBuiltInFunctions(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`regex("a+b")`, "/a+b/"},
+		{`typeOf(regex("a"))`, "REGEX"},
+		{`match(regex("^\d+$"), "123")`, "true"},
+		{`match("^\d+$", "12a")`, "false"},
+		{`findAll(regex("\d+"), "a1 b22 c333")`, "[1, 22, 333]"},
+		{`findAll("x", "abc")`, "[]"},
+		{`replaceAll(regex("(\w+)@(\w+)"), "ana@home bo@work", "$2:$1")`, "home:ana work:bo"},
+		{`captureGroups(regex("(?P<level>[A-Z]+) (\d+)"), "ERROR 42 disk full")`, "{level: ERROR, 2: 42}"},
+		{`captureGroups("(?P<level>[A-Z]+)", "no match")`, "null"},
+		{`captureGroups("(a)|(b)", "b")`, "{1: null, 2: b}"},
+		{`var map groups = captureGroups("(?P<user>\w+)@", "ana@home"); groups["user"];`, "ana"},
+		{`regex("a") == regex("a")`, "true"},
+		{`regex("(")`, "Invalid regular expression \"(\": error parsing regexp: missing closing ): `(`"},
+		{`match("(", "a")`, "Invalid regular expression \"(\": error parsing regexp: missing closing ): `(`"},
+		{`match(1, "a")`, "First argument to `match` must be a Regex or a String, got INTEGER"},
+		{`findAll("a", 1)`, "Second argument to `findAll` must be a String, got INTEGER"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		if errObj, ok := evaluated.(*object.Error); ok {
+			if errObj.Message != tt.expected {
+				t.Errorf("%s: wrong error message. Expected %q, got %q", tt.input, tt.expected, errObj.Message)
+			}
+			continue
+		}
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestRegexCache(t *testing.T) {
+	first, second := New(), New()
+
+	regex := testEvalWith(first, `regex("a+")`)
+	if testEvalWith(first, `regex("a+")`) != regex {
+		t.Errorf("the same pattern was compiled twice by one interpreter")
+	}
+
+	if testEvalWith(second, `regex("a+")`) == regex {
+		t.Errorf("interpreters share their compiled patterns")
+	}
+}
+
+func TestTimeBuiltInFunctions(t *testing.T) {
+	start := time.Date(2024, time.March, 10, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`now()`, "2024-03-10T12:30:00Z"},
+		{`formatTime(now(), "2006-01-02 15:04")`, "2024-03-10 12:30"},
+		{`formatTime(now())`, "2024-03-10T12:30:00Z"},
+		{`now() + 1500`, "2024-03-10T12:30:01.5Z"},
+		{`60000 + now()`, "2024-03-10T12:31:00Z"},
+		{`now() - 86400000`, "2024-03-09T12:30:00Z"},
+		{`parseTime("2024-03-11", "2006-01-02") - now()`, "41400000"},
+		{`parseTime("2024-03-10T12:30:00Z") == now()`, "true"},
+		{`now() < now() + 1`, "true"},
+		{`now() >= now()`, "true"},
+		{`now() > now() + 1`, "false"},
+		{`now() == 5`, "false"},
+		{`var time t = now(); sleep(2000); now() - t;`, "2000"},
+		{`sort([parseTime("2024-05-01", "2006-01-02"), parseTime("2023-01-01", "2006-01-02")])`, "[2023-01-01T00:00:00Z, 2024-05-01T00:00:00Z]"},
+		{`typeOf(now())`, "TIME"},
+		{`now() * 2`, "Unknown operator: TIME * INTEGER"},
+		{`"t=" + now()`, "t=2024-03-10T12:30:00Z"},
+		{`now() + " UTC"`, "2024-03-10T12:30:00Z UTC"},
+		{`now() - "1"`, "Unknown operator: TIME - STRING"},
+		{`parseTime("soon", "2006-01-02")`, `Could not parse "soon" as a time with the layout "2006-01-02"`},
+		{`formatTime("2024")`, "First argument to `formatTime` must be a Time, got STRING"},
+		{`sleep(-1)`, "Cannot sleep a negative number of milliseconds: -1"},
+	}
+
+	for _, tt := range tests {
+		//	the clock only moves when the program sleeps
+		current := start
+		interpreter := New()
+		interpreter.Clock = func() time.Time { return current }
+		interpreter.Sleep = func(d time.Duration) { current = current.Add(d) }
+
+		evaluated := testEvalWith(interpreter, tt.input)
+
+		if errObj, ok := evaluated.(*object.Error); ok {
+			if errObj.Message != tt.expected {
+				t.Errorf("%s: wrong error message. Expected %q, got %q", tt.input, tt.expected, errObj.Message)
+			}
+			continue
+		}
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestEncodingBuiltInFunctions(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`sha256("hello")`, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+		{`md5("hello")`, "5d41402abc4b2a76b9719d911017c592"},
+		{`base64Encode("hello world")`, "aGVsbG8gd29ybGQ="},
+		{`base64Decode("aGVsbG8gd29ybGQ=")`, "hello world"},
+		{`hexEncode("hi")`, "6869"},
+		{`hexDecode("6869")`, "hi"},
+		{`urlEncode("a b&c=d/é")`, "a+b%26c%3Dd%2F%C3%A9"},
+		{`urlDecode("a+b%26c")`, "a b&c"},
+		{`base64Decode(base64Encode("round trip"))`, "round trip"},
+		{`match("^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$", uuid())`, "true"},
+		{`uuid() == uuid()`, "false"},
+		{`base64Decode("not base64!")`, `Could not decode "not base64!" as base64: illegal base64 data at input byte 3`},
+		{`hexDecode("zz")`, `Could not decode "zz" as hexadecimal: encoding/hex: invalid byte: U+007A 'z'`},
+		{`urlDecode("%zz")`, `Could not decode "%zz" as a URL: invalid URL escape "%zz"`},
+		{`sha256(5)`, "First argument to `sha256` must be a String, got INTEGER"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		if errObj, ok := evaluated.(*object.Error); ok {
+			if errObj.Message != tt.expected {
+				t.Errorf("%s: wrong error message. Expected %q, got %q", tt.input, tt.expected, errObj.Message)
+			}
+			continue
+		}
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestModules(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"utils.smp": `var array called = [];
+export const fn helper = func(x) { push(called, x); return x * 2; };
+export var fn calls = func() { return length(called); };
+var int hidden = 1;`,
+		"lib/strs.smp": `export const fn shout = func(s) { return upper(s) + "!"; };`,
+		"a.smp": `import "b.smp" as b;`,
+		"b.smp": `import "a.smp" as a;`,
+		"failing.smp": `var int x = 1 / 0;`,
+		"broken.smp": `var int = 1;`,
+	}
+
+	for name, source := range files {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`import "utils.smp" as utils; utils.helper(4);`, "8"},
+		{`import "utils.smp" as utils; import "utils.smp" as again; again.helper(1); utils.calls();`, "2"},
+		{`import "strs.smp" as strs; strs.shout("hi");`, "HI!"},
+		{`import "lib/strs.smp" as strs; strs.shout("hey");`, "HEY!"},
+		{`import "utils.smp" as utils; utils.hidden;`, "Module utils.smp does not export hidden"},
+		{`import "missing.smp" as missing;`, "Module not found: missing.smp"},
+		{`import "a.smp" as a;`, "Import cycle: a.smp -> b.smp -> a.smp"},
+		{`import "failing.smp" as failing;`, "Error: division by zero not supported"},
+		{`import "broken.smp" as broken;`, "Could not parse module broken.smp at line 1, column 9"},
+		{`var int x = 1; x.y;`, "INTEGER has no method y"},
+	}
+
+	interpreter := New()
+	interpreter.AllowFileSystem = true
+	interpreter.Paths = []string{ filepath.Join(dir, "lib") }
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := parser.New(l)
+		program := p.ParserProgram()
+		//	the program runs as if it was a file in the temporary directory
+		evaluated := interpreter.EvalFile(filepath.Join(dir, "main.smp"), program, object.NewEnvironment())
+
+		if errObj, ok := evaluated.(*object.Error); ok {
+			if errObj.Message != tt.expected {
+				t.Errorf("%s: wrong error message. Expected %q, got %q", tt.input, tt.expected, errObj.Message)
+			}
+			continue
+		}
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestSandboxedImports(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"outside.smp": `export const string secret = "hidden";`,
+		"program/utils.smp": `export const int value = 1;`,
+		"program/nested/deep.smp": `export const int value = 2;`,
+	}
+
+	for name, source := range files {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	os.Symlink(filepath.Join(dir, "outside.smp"), filepath.Join(dir, "program", "link.smp"))
+
+	disabled := ". File system access is disabled, so only files in the directory of the program can be imported"
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`import "utils.smp" as utils; utils.value;`, "1"},
+		{`import "nested/deep.smp" as deep; deep.value;`, "2"},
+		{`import "../outside.smp" as outside;`, "Module not found: ../outside.smp" + disabled},
+		{`import "link.smp" as link;`, "Module not found: link.smp" + disabled},
+		{`import "` + filepath.Join(dir, "outside.smp") + `" as outside;`, "Module not found: " + filepath.Join(dir, "outside.smp") + disabled},
+		{`import "outside.smp" as outside;`, "Module not found: outside.smp" + disabled},
+	}
+
+	interpreter := New()
+	interpreter.Paths = []string{ dir }
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := parser.New(l)
+		program := p.ParserProgram()
+		evaluated := interpreter.EvalFile(filepath.Join(dir, "program", "main.smp"), program, object.NewEnvironment())
+
+		if errObj, ok := evaluated.(*object.Error); ok {
+			if errObj.Message != tt.expected {
+				t.Errorf("%s: wrong error message. Expected %q, got %q", tt.input, tt.expected, errObj.Message)
+			}
+			continue
+		}
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestModuleIsEvaluatedOnce(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "noisy.smp"), []byte(`print("loaded");`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	interpreter := New()
+	interpreter.Out = &out
+
+	program := parser.New(lexer.New(`import "noisy.smp" as one; import "noisy.smp" as two;`)).ParserProgram()
+	interpreter.EvalFile(filepath.Join(dir, "main.smp"), program, object.NewEnvironment())
+
+	if out.String() != "loaded\n" {
+		t.Errorf("expected the module to run once, got output %q", out.String())
+	}
+}
+
+func TestMethodCalls(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`[1, 2, 3].length()`, "3"},
+		{`var array arr = [3, 1, 2]; arr.sort().map(func(x) { return x * 10; });`, "[10, 20, 30]"},
+		{`[1, 2, 3, 4].filter(func(x) { return x > 2; }).sum()`, "7"},
+		{`var array arr = [1]; arr.push(2); arr;`, "[1, 2]"},
+		{`["a", "b"].join("-")`, "a-b"},
+		{`"  Hello ".trim().upper()`, "HELLO"},
+		{`"a,b,c".split(",").length()`, "3"},
+		{`var string name = "simpl"; name.startsWith("sim");`, "true"},
+		{`"héllo".length()`, "5"},
+		{`var map m = {"a": 1, "b": 2}; m.keys();`, "[a, b]"},
+		{`{"a": 1}.has("a")`, "true"},
+		{`var map config = {"port": 8080, "host": "localhost"}; config.port;`, "8080"},
+		{`var map config = {"port": 8080}; config.host;`, "null"},
+		{`var map m = {"keys": "mine"}; m.keys;`, "mine"},
+		{`var map m = {"a": {"b": 5}}; m.a.b;`, "5"},
+		{`var map m = {"a": 1}; m.length();`, "1"},
+		{`[1, 2].upper()`, "ARRAY has no method upper"},
+		{`"abc".push("d")`, "STRING has no method push"},
+		{`true.length()`, "BOOLEAN has no method length"},
+		{`[1].push()`, "Wrong number of arguments. Got 1, expected 2"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		if errObj, ok := evaluated.(*object.Error); ok {
+			if errObj.Message != tt.expected {
+				t.Errorf("%s: wrong error message. Expected %q, got %q", tt.input, tt.expected, errObj.Message)
+			}
+			continue
+		}
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestMethodCallStackTrace(t *testing.T) {
+	evaluated := testEval(`var array arr = [1, 2];
+arr.map(func(x) { return x / 0; });`)
+
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("expected an error, got %T (%+v)", evaluated, evaluated)
+	}
+
+	if len(errObj.Stack) == 0 || errObj.Stack[len(errObj.Stack) - 1].Function != "arr.map" {
+		t.Errorf("expected the method call in the stack, got %+v", errObj.Stack)
+	}
+}
+
+func TestStructs(t *testing.T) {
+	definitions := `struct Point { int x; int y; }
+struct Line { Point start; Point end; }
+struct Named { string name; fn greet; }
+`
+
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`Point{x: 1, y: 2}`, "Point{x: 1, y: 2}"},
+		{`Point{y: 2, x: 1}`, "Point{x: 1, y: 2}"},
+		{`var Point p = Point{x: 1, y: 2}; p.y;`, "2"},
+		{`var Line l = Line{start: Point{x: 0, y: 0}, end: Point{x: 3, y: 4}}; l.end.x;`, "3"},
+		{`Point{x: 1, y: 2} == Point{x: 1, y: 2}`, "true"},
+		{`Point{x: 1, y: 2} == Point{x: 2, y: 1}`, "false"},
+		{`Point{x: 1, y: 2} != Point{x: 1, y: 3}`, "true"},
+		{`typeOf(Point{x: 1, y: 2})`, "Point"},
+		{`Point`, "struct Point { int x; int y; }"},
+		{`var Named n = Named{name: "ana", greet: func(s) { return "hi " + s; }}; n.greet(n.name);`, "hi ana"},
+		{`Point{x: 1}`, "Missing field y of Point"},
+		{`Point{x: 1, y: 2, z: 3}`, "Point has no field z"},
+		{`Point{x: 1, y: "2"}`, "Field y of Point must be int, got STRING"},
+		{`Line{start: Point{x: 0, y: 0}, end: 5}`, "Field end of Line must be Point, got INTEGER"},
+		{`var Point p = Point{x: 1, y: 2}; p.z;`, "Point has no field z"},
+		{`var Point p = [1, 2][0];`, "Expected type Point, got INTEGER"},
+		{`var Point p = Point{x: 1, y: 2}; p = Line{start: p, end: p};`, "Cannot reassign different types. Passed Line type to Point type variable"},
+		{`var int x = 1; x{a: 1};`, "x is not a struct, got INTEGER"},
+		{`struct STRING { string s; } var STRING s = STRING{s: "a"}; s + "b";`, "STRING{s: a}b"},
+		{`struct ERROR { string message; } var ERROR e = ERROR{message: "a"}; e.message;`, "a"},
+		{`struct MAP { int a; } var MAP m = MAP{a: 1}; keys(m);`, "Argument to `keys` must be a Map, got RECORD"},
+		{`struct MAP { int a; } var MAP m = MAP{a: 1}; typeOf(m);`, "MAP"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(definitions + tt.input)
+
+		if errObj, ok := evaluated.(*object.Error); ok {
+			if errObj.Message != tt.expected {
+				t.Errorf("%s: wrong error message. Expected %q, got %q", tt.input, tt.expected, errObj.Message)
+			}
+			continue
+		}
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
 }
@@ -0,0 +1,157 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"language/object"
+	"os"
+	"strings"
+)
+
+//	fileSystemBuiltin makes a builtin that can only run when the interpreter allows file system access
+func fileSystemBuiltin(name string, fn func(args ...object.Object) object.Object) *builtin {
+	return &builtin{
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if !in.AllowFileSystem {
+				return newError("File system access is disabled, `%s` cannot be used", name)
+			}
+
+			return fn(args...)
+		},
+	}
+}
+
+//	ioError makes an error from a failed file system operation
+func ioError(action, path string, err error) *object.Error {
+	//	the path is already in the message, so only the reason is kept
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		err = pathErr.Err
+	}
+
+	return &object.Error{ Message: fmt.Sprintf("Could not %s %q: %s", action, path, err), Kind: IO_ERROR }
+}
+
+func writeToFile(name string, args []object.Object, flag int) object.Object {
+	values, err := stringArguments(name, args, 2)
+	if err != nil {
+		return err
+	}
+
+	file, openErr := os.OpenFile(values[0], flag, 0644)
+	if openErr != nil {
+		return ioError("open", values[0], openErr)
+	}
+	defer file.Close()
+
+	if _, writeErr := file.WriteString(values[1]); writeErr != nil {
+		return ioError("write to", values[0], writeErr)
+	}
+
+	return NULL
+}
+
+var fileSystemBuiltins = map[string]*builtin{
+	"readFile": fileSystemBuiltin("readFile", func(args ...object.Object) object.Object {
+		values, err := stringArguments("readFile", args, 1)
+		if err != nil {
+			return err
+		}
+
+		content, readErr := os.ReadFile(values[0])
+		if readErr != nil {
+			return ioError("read", values[0], readErr)
+		}
+
+		return &object.String{ Value: string(content) }
+	}),
+	"writeFile": fileSystemBuiltin("writeFile", func(args ...object.Object) object.Object {
+		//	the file is created if it does not exist, and replaced if it does
+		return writeToFile("writeFile", args, os.O_WRONLY | os.O_CREATE | os.O_TRUNC)
+	}),
+	"appendFile": fileSystemBuiltin("appendFile", func(args ...object.Object) object.Object {
+		return writeToFile("appendFile", args, os.O_WRONLY | os.O_CREATE | os.O_APPEND)
+	}),
+	"readLines": fileSystemBuiltin("readLines", func(args ...object.Object) object.Object {
+		values, err := stringArguments("readLines", args, 1)
+		if err != nil {
+			return err
+		}
+
+		content, readErr := os.ReadFile(values[0])
+		if readErr != nil {
+			return ioError("read", values[0], readErr)
+		}
+
+		if len(content) == 0 {
+			return &object.Array{ Elements: []object.Object{} }
+		}
+		//	a line break at the end of the file does not start another line
+		lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimSuffix(line, "\r")
+		}
+
+		return stringArray(lines)
+	}),
+	"exists": fileSystemBuiltin("exists", func(args ...object.Object) object.Object {
+		values, err := stringArguments("exists", args, 1)
+		if err != nil {
+			return err
+		}
+
+		_, statErr := os.Stat(values[0])
+		if errors.Is(statErr, fs.ErrNotExist) {
+			return FALSE
+		}
+
+		if statErr != nil {
+			return ioError("check", values[0], statErr)
+		}
+
+		return TRUE
+	}),
+	"listDir": fileSystemBuiltin("listDir", func(args ...object.Object) object.Object {
+		values, err := stringArguments("listDir", args, 1)
+		if err != nil {
+			return err
+		}
+		//	the entries come sorted by name
+		entries, readErr := os.ReadDir(values[0])
+		if readErr != nil {
+			return ioError("list", values[0], readErr)
+		}
+
+		names := make([]string, len(entries))
+		for i, entry := range entries {
+			names[i] = entry.Name()
+		}
+
+		return stringArray(names)
+	}),
+	"mkdir": fileSystemBuiltin("mkdir", func(args ...object.Object) object.Object {
+		values, err := stringArguments("mkdir", args, 1)
+		if err != nil {
+			return err
+		}
+		//	makes the parent directories too, and does nothing if the directory exists
+		if mkdirErr := os.MkdirAll(values[0], 0755); mkdirErr != nil {
+			return ioError("make directory", values[0], mkdirErr)
+		}
+
+		return NULL
+	}),
+	"remove": fileSystemBuiltin("remove", func(args ...object.Object) object.Object {
+		values, err := stringArguments("remove", args, 1)
+		if err != nil {
+			return err
+		}
+		//	only files and empty directories are removed
+		if removeErr := os.Remove(values[0]); removeErr != nil {
+			return ioError("remove", values[0], removeErr)
+		}
+
+		return NULL
+	}),
+}
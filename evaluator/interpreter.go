@@ -0,0 +1,123 @@
+package evaluator
+
+import (
+	"bufio"
+	"io"
+	"language/ast"
+	"language/object"
+	"math/rand"
+	"os"
+	"time"
+)
+
+//	Interpreter evaluates programs and holds the builtins bound to it,
+//	so builtins like map or filter can call back into user functions
+type Interpreter struct {
+	builtins map[string]*object.BuiltIn
+	//	StrictIndexing makes indexing out of range an error instead of null
+	StrictIndexing bool
+	//	AllowFileSystem lets the file system builtins read and write files.
+	//	It is off by default, so sandboxed programs cannot touch the disk
+	AllowFileSystem bool
+	//	Args are the command line arguments passed to the program
+	Args []string
+	//	Stdin is where input and readStdin read from, set it before running a program
+	Stdin io.Reader
+	//	Out is where print and the other output builtins write, and ErrOut is where eprint writes
+	Out io.Writer
+	ErrOut io.Writer
+	//	reader buffers Stdin, so what input reads ahead is not lost
+	reader *bufio.Reader
+	//	Clock tells the time to now, and Sleep waits for sleep. They can be replaced
+	//	so programs that use the time give the same results every run
+	Clock func() time.Time
+	Sleep func(time.Duration)
+	//	regexes caches the compiled patterns by their source
+	regexes map[string]*object.Regex
+	//	random is the generator of the random builtins, each interpreter has its own
+	random *rand.Rand
+	//	Paths are the directories imports are looked up in when the
+	//	file is not found next to the one importing it
+	Paths []string
+	//	modules caches the imported modules by the absolute path of their file
+	modules map[string]*object.Module
+	//	files are the files being evaluated, the last one is the one running now
+	files []string
+	//	calls is how many functions of the file running now have not returned yet,
+	//	? can only return early from one of them
+	calls int
+}
+
+//	builtin is a builtin function that receives the interpreter running it
+type builtin struct {
+	Fn func(in *Interpreter, args ...object.Object) object.Object
+}
+
+//	the builtins of every module, bound to each interpreter made
+var builtinGroups = []map[string]*builtin{
+	builtins,
+	stringBuiltins,
+	conversionBuiltins,
+	mathBuiltins,
+	randomBuiltins,
+	fileSystemBuiltins,
+	systemBuiltins,
+	jsonBuiltins,
+	csvBuiltins,
+	regexBuiltins,
+	timeBuiltins,
+	encodingBuiltins,
+}
+
+func New() *Interpreter {
+	in := &Interpreter{
+		builtins: make(map[string]*object.BuiltIn),
+		regexes: make(map[string]*object.Regex),
+		modules: make(map[string]*object.Module),
+		random: rand.New(rand.NewSource(time.Now().UnixNano())),
+		Args: []string{},
+		Paths: []string{},
+		Clock: time.Now,
+		Sleep: time.Sleep,
+		Stdin: os.Stdin,
+		Out: os.Stdout,
+		ErrOut: os.Stderr,
+	}
+
+	for _, group := range builtinGroups {
+		for name, b := range group {
+			fn := b.Fn
+			in.builtins[name] = &object.BuiltIn{
+				Fn: func(args ...object.Object) object.Object {
+					return fn(in, args...)
+				},
+			}
+		}
+	}
+
+	return in
+}
+
+//	Seed resets the random generator, so the random builtins give the same values on every run
+func (in *Interpreter) Seed(seed int64) {
+	in.random = rand.New(rand.NewSource(seed))
+}
+
+//	stdin returns the buffered reader of Stdin, made the first time it is read
+func (in *Interpreter) stdin() *bufio.Reader {
+	if in.reader == nil {
+		in.reader = bufio.NewReader(in.Stdin)
+	}
+
+	return in.reader
+}
+
+//	Eval evaluates the node with a new interpreter
+func Eval(node ast.Node, env *object.Environment) object.Object {
+	return New().Eval(node, env)
+}
+
+//	callFunction calls a function passed to a builtin with the given arguments
+func (in *Interpreter) callFunction(fn object.Object, args ...object.Object) object.Object {
+	return in.applyFunction(fn, args, nil)
+}
@@ -0,0 +1,243 @@
+package evaluator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"language/object"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+//	parseJSONValue reads the next value from the decoder. Objects are read token by token,
+//	so the maps keep the keys in the order they were written
+func parseJSONValue(decoder *json.Decoder) (object.Object, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token := token.(type) {
+	case json.Delim:
+		switch token {
+		case '[':
+			elements := []object.Object{}
+			for decoder.More() {
+				element, err := parseJSONValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+
+				elements = append(elements, element)
+			}
+			//	reads the closing bracket
+			if _, err := decoder.Token(); err != nil {
+				return nil, err
+			}
+
+			return &object.Array{ Elements: elements }, nil
+		case '{':
+			jsonMap := object.NewMap()
+			for decoder.More() {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+
+				value, err := parseJSONValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+
+				jsonMap.Set(&object.String{ Value: key.(string) }, value)
+			}
+			//	reads the closing brace
+			if _, err := decoder.Token(); err != nil {
+				return nil, err
+			}
+
+			return jsonMap, nil
+		}
+	case json.Number:
+		//	numbers without a decimal part or exponent are integers
+		if !strings.ContainsAny(token.String(), ".eE") {
+			value, ok := new(big.Int).SetString(token.String(), 10)
+			if ok {
+				return normalizeBigInt(value), nil
+			}
+		}
+
+		value, err := token.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("number %s is out of range", token)
+		}
+
+		return &object.Double{ Value: value }, nil
+	case string:
+		return &object.String{ Value: token }, nil
+	case bool:
+		return nativeBoolToBooleaObject(token), nil
+	case nil:
+		return NULL, nil
+	}
+
+	return nil, fmt.Errorf("unexpected %v", token)
+}
+
+//	jsonString writes a string with the escapes JSON needs
+func jsonString(out *bytes.Buffer, value string) {
+	encoder := json.NewEncoder(out)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(value)
+	//	the encoder ends every value with a line break
+	out.Truncate(out.Len() - 1)
+}
+
+//	jsonKey turns a map key into the string JSON needs for it
+func jsonKey(key object.Object) (string, error) {
+	switch key := key.(type) {
+	case *object.String:
+		return key.Value, nil
+	case *object.Integer, *object.BigInt, *object.Double, *object.Boolean:
+		return key.Inspect(), nil
+	}
+
+	return "", fmt.Errorf("Cannot convert a map key of type %s to JSON", key.Type())
+}
+
+//	writeJSON walks the object, keeping the arrays and maps being written to stop on cycles
+func writeJSON(out *bytes.Buffer, obj object.Object, visiting map[object.Object]bool) error {
+	switch obj := obj.(type) {
+	case *object.Integer, *object.BigInt, *object.Boolean:
+		out.WriteString(obj.Inspect())
+	case *object.Double:
+		if math.IsNaN(obj.Value) || math.IsInf(obj.Value, 0) {
+			return fmt.Errorf("Cannot convert %s to JSON", obj.Inspect())
+		}
+
+		out.WriteString(strconv.FormatFloat(obj.Value, 'g', -1, 64))
+	case *object.String:
+		jsonString(out, obj.Value)
+	case *object.Null:
+		out.WriteString("null")
+	case *object.Array:
+		if visiting[obj] {
+			return errors.New("Cannot convert an array that contains itself to JSON")
+		}
+		visiting[obj] = true
+		defer delete(visiting, obj)
+
+		out.WriteString("[")
+		for i, element := range obj.Elements {
+			if i > 0 {
+				out.WriteString(",")
+			}
+
+			if err := writeJSON(out, element, visiting); err != nil {
+				return err
+			}
+		}
+		out.WriteString("]")
+	case *object.Map:
+		if visiting[obj] {
+			return errors.New("Cannot convert a map that contains itself to JSON")
+		}
+		visiting[obj] = true
+		defer delete(visiting, obj)
+
+		out.WriteString("{")
+		for i, pair := range obj.Pairs() {
+			if i > 0 {
+				out.WriteString(",")
+			}
+
+			key, err := jsonKey(pair.Key)
+			if err != nil {
+				return err
+			}
+
+			jsonString(out, key)
+			out.WriteString(":")
+
+			if err := writeJSON(out, pair.Value, visiting); err != nil {
+				return err
+			}
+		}
+		out.WriteString("}")
+	default:
+		return fmt.Errorf("Cannot convert %s to JSON", obj.Type())
+	}
+
+	return nil
+}
+
+var jsonBuiltins = map[string]*builtin{
+	"jsonParse": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			values, err := stringArguments("jsonParse", args, 1)
+			if err != nil {
+				return err
+			}
+
+			decoder := json.NewDecoder(strings.NewReader(values[0]))
+			decoder.UseNumber()
+
+			if strings.TrimSpace(values[0]) == "" {
+				return newError("Could not parse JSON: the string is empty")
+			}
+
+			value, parseErr := parseJSONValue(decoder)
+			if errors.Is(parseErr, io.EOF) || errors.Is(parseErr, io.ErrUnexpectedEOF) {
+				return newError("Could not parse JSON: unexpected end of the string")
+			}
+
+			if parseErr != nil {
+				return newError("Could not parse JSON: %s", parseErr)
+			}
+			//	only one value can be in the string
+			if _, extraErr := decoder.Token(); extraErr != io.EOF {
+				return newError("Could not parse JSON: unexpected data after the value")
+			}
+
+			return value
+		},
+	},
+	"jsonStringify": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 1 && len(args) != 2 {
+				return newError("Wrong number of arguments. Expected 1 or 2, got %d", len(args))
+			}
+
+			var out bytes.Buffer
+			if err := writeJSON(&out, args[0], make(map[object.Object]bool)); err != nil {
+				return newError("%s", err)
+			}
+
+			if len(args) == 1 {
+				return &object.String{ Value: out.String() }
+			}
+			//	the indent is a number of spaces or the string to indent with
+			var indent string
+			switch arg := args[1].(type) {
+			case *object.Integer:
+				if arg.Value < 0 {
+					return newError("Indent passed to `jsonStringify` cannot be negative")
+				}
+				indent = strings.Repeat(" ", int(arg.Value))
+			case *object.String:
+				indent = arg.Value
+			default:
+				return newError("Second argument to `jsonStringify` must be an Integer or a String, got %s", args[1].Type())
+			}
+
+			var indented bytes.Buffer
+			json.Indent(&indented, out.Bytes(), "", indent)
+
+			return &object.String{ Value: indented.String() }
+		},
+	},
+}
@@ -0,0 +1,265 @@
+package evaluator
+
+import (
+	"language/object"
+	"math"
+	"math/big"
+)
+
+//	maxIntegerBits is the size of the biggest integer pow makes,
+//	so a huge exponent is an error and not a program that never ends
+const maxIntegerBits = 1 << 20
+
+//	constants are values every program can use by name
+var constants = map[string]object.Object{
+	"PI": &object.Double{ Value: math.Pi },
+	"E": &object.Double{ Value: math.E },
+}
+
+//	compareNumbers returns -1, 0 or 1 if the left number is smaller, equal or bigger than the right one
+func compareNumbers(left, right object.Object) int {
+	if isInteger(left) && isInteger(right) {
+		return toBigInt(left).Cmp(toBigInt(right))
+	}
+
+	leftValue, rightValue := toFloat(left), toFloat(right)
+	if leftValue < rightValue {
+		return -1
+	} else if leftValue > rightValue {
+		return 1
+	}
+
+	return 0
+}
+
+//	numberArguments checks that the builtin received the given number of numbers
+func numberArguments(name string, args []object.Object, count int) *object.Error {
+	if len(args) != count {
+		return newError("Wrong number of arguments. Got %d, expected %d", len(args), count)
+	}
+
+	for i, arg := range args {
+		if !isNumber(arg) {
+			return newError("%s argument to `%s` must be a number, got %s", ordinals[i], name, arg.Type())
+		}
+	}
+
+	return nil
+}
+
+//	numberList returns the numbers passed to builtins that take either many numbers or an array of them
+func numberList(name string, args []object.Object) ([]object.Object, *object.Error) {
+	if len(args) == 1 {
+		if array, ok := args[0].(*object.Array); ok {
+			args = array.Elements
+		}
+	}
+
+	if len(args) == 0 {
+		return nil, newError("`%s` needs at least one number", name)
+	}
+
+	for _, arg := range args {
+		if !isNumber(arg) {
+			return nil, newError("Arguments passed to `%s` must be numbers, got %s", name, arg.Type())
+		}
+	}
+
+	return args, nil
+}
+
+//	extreme returns the number that compares as the given order against every other one
+func extreme(name string, args []object.Object, order int) object.Object {
+	numbers, err := numberList(name, args)
+	if err != nil {
+		return err
+	}
+
+	result := numbers[0]
+	for _, number := range numbers[1:] {
+		if compareNumbers(number, result) == order {
+			result = number
+		}
+	}
+
+	return result
+}
+
+//	doubleFunction makes a builtin that applies a function on doubles to a number
+func doubleFunction(name string, fn func(float64) float64) *builtin {
+	return &builtin{
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if err := numberArguments(name, args, 1); err != nil {
+				return err
+			}
+
+			return &object.Double{ Value: fn(toFloat(args[0])) }
+		},
+	}
+}
+
+//	roundingFunction makes a builtin that rounds a number to an integer.
+//	Integers are already whole, so they are returned as they are
+func roundingFunction(name string, fn func(float64) float64) *builtin {
+	return &builtin{
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if err := numberArguments(name, args, 1); err != nil {
+				return err
+			}
+
+			if isInteger(args[0]) {
+				return args[0]
+			}
+
+			return toInteger(&object.Double{ Value: fn(toFloat(args[0])) })
+		},
+	}
+}
+
+//	sumNumbers adds the elements of an array, keeping integers as integers
+func sumNumbers(name string, args []object.Object) (object.Object, int, *object.Error) {
+	if len(args) != 1 {
+		return nil, 0, newError("Wrong number of arguments. Got %d, expected 1", len(args))
+	}
+
+	array, ok := args[0].(*object.Array)
+	if !ok {
+		return nil, 0, newError("Argument to `%s` must be an Array, got %s", name, args[0].Type())
+	}
+
+	var total object.Object = &object.Integer{ Value: 0 }
+	for _, element := range array.Elements {
+		if !isNumber(element) {
+			return nil, 0, newError("Elements passed to `%s` must be numbers, got %s", name, element.Type())
+		}
+
+		total = evalInfixExpression("+", total, element)
+	}
+
+	return total, len(array.Elements), nil
+}
+
+var mathBuiltins = map[string]*builtin{
+	"abs": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if err := numberArguments("abs", args, 1); err != nil {
+				return err
+			}
+
+			if compareNumbers(args[0], &object.Integer{ Value: 0 }) < 0 {
+				return evalMinusPrefixOperatorExpression(args[0])
+			}
+
+			return args[0]
+		},
+	},
+	"min": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			return extreme("min", args, -1)
+		},
+	},
+	"max": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			return extreme("max", args, 1)
+		},
+	},
+	"clamp": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if err := numberArguments("clamp", args, 3); err != nil {
+				return err
+			}
+
+			value, low, high := args[0], args[1], args[2]
+			if compareNumbers(low, high) > 0 {
+				return newError("The lower bound passed to `clamp` is bigger than the upper bound")
+			}
+
+			if compareNumbers(value, low) < 0 {
+				return low
+			}
+
+			if compareNumbers(value, high) > 0 {
+				return high
+			}
+
+			return value
+		},
+	},
+	"sqrt": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if err := numberArguments("sqrt", args, 1); err != nil {
+				return err
+			}
+
+			if compareNumbers(args[0], &object.Integer{ Value: 0 }) < 0 {
+				return newError("Cannot take the square root of a negative number: %s", args[0].Inspect())
+			}
+
+			return &object.Double{ Value: math.Sqrt(toFloat(args[0])) }
+		},
+	},
+	"floor": roundingFunction("floor", math.Floor),
+	"ceil": roundingFunction("ceil", math.Ceil),
+	"round": roundingFunction("round", math.Round),
+	"pow": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if err := numberArguments("pow", args, 2); err != nil {
+				return err
+			}
+
+			base, exponent := args[0], args[1]
+			//	integers to a positive integer power stay integers
+			if isInteger(base) && isInteger(exponent) && toBigInt(exponent).Sign() >= 0 {
+				//	the result has about as many bits as the base times the exponent,
+				//	while 0, 1 and -1 stay as small whatever the exponent is
+				bits := int64(toBigInt(base).BitLen())
+				if !toBigInt(exponent).IsInt64() || bits > 1 && toBigInt(exponent).Int64() > maxIntegerBits / bits {
+					return newError("Exponent passed to `pow` is too big: %s", exponent.Inspect())
+				}
+
+				return normalizeBigInt(new(big.Int).Exp(toBigInt(base), toBigInt(exponent), nil))
+			}
+
+			return &object.Double{ Value: math.Pow(toFloat(base), toFloat(exponent)) }
+		},
+	},
+	"log": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if err := numberArguments("log", args, 1); err != nil {
+				return err
+			}
+
+			if compareNumbers(args[0], &object.Integer{ Value: 0 }) <= 0 {
+				return newError("Cannot take the logarithm of a number that is not positive: %s", args[0].Inspect())
+			}
+
+			return &object.Double{ Value: math.Log(toFloat(args[0])) }
+		},
+	},
+	"sin": doubleFunction("sin", math.Sin),
+	"cos": doubleFunction("cos", math.Cos),
+	"sum": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			total, _, err := sumNumbers("sum", args)
+			if err != nil {
+				return err
+			}
+
+			return total
+		},
+	},
+	"avg": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			total, count, err := sumNumbers("avg", args)
+			if err != nil {
+				return err
+			}
+
+			if count == 0 {
+				return newError("Cannot take the average of an empty array")
+			}
+			//	the average is always a double
+			return &object.Double{ Value: toFloat(total) / float64(count) }
+		},
+	},
+}
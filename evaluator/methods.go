@@ -0,0 +1,91 @@
+package evaluator
+
+import (
+	"language/ast"
+	"language/object"
+	"path/filepath"
+)
+
+//	methodTable makes the set of builtins a type can call as methods
+func methodTable(names ...string) map[string]bool {
+	table := make(map[string]bool)
+	for _, name := range names {
+		table[name] = true
+	}
+
+	return table
+}
+
+//	methods are the builtins each type can call with a dot, like arr.length().
+//	The value before the dot is passed to the builtin as its first argument
+var methods = map[object.ObjectType]map[string]bool{
+	object.ARRAY_OBJECT: methodTable(
+		"length", "firstElement", "lastElement", "push", "removeLast", "removeAt", "copy",
+		"map", "filter", "reduce", "forEach", "find", "findIndex", "any", "all", "sort",
+		"join", "sum", "avg", "min", "max", "choice", "shuffle",
+	),
+	object.STRING_OBJECT: methodTable(
+		"length", "split", "trim", "upper", "lower", "replace", "contains", "startsWith",
+		"endsWith", "indexOf", "repeat", "padLeft", "padRight", "chars", "format",
+	),
+	object.MAP_OBJECT: methodTable(
+		"length", "keys", "values", "entries", "has", "delete", "set", "merge",
+	),
+}
+
+//	method returns the builtin with the given name bound to the receiver,
+//	if the type of the receiver has it as a method
+func (in *Interpreter) method(receiver object.Object, name string) (*object.BuiltIn, bool) {
+	if !methods[receiver.Type()][name] {
+		return nil, false
+	}
+
+	fn := in.builtins[name].Fn
+	return &object.BuiltIn{
+		Fn: func(args ...object.Object) object.Object {
+			return fn(append([]object.Object{ receiver }, args...)...)
+		},
+	}, true
+}
+
+func (in *Interpreter) evalMemberExpression(node *ast.MemberExpression, env *object.Environment) object.Object {
+	left := in.Eval(node.Left, env)
+	if isReturnOrError(left) {
+		return left
+	}
+
+	name := node.Property.Value
+
+	switch left := left.(type) {
+	case *object.Module:
+		if value, ok := left.Get(name); ok {
+			return value
+		}
+
+		return withPosition(
+			newError("Module %s does not export %s", filepath.Base(left.Path), name),
+			node.Property.Token,
+		)
+	case *object.Record:
+		if value, ok := left.Fields[name]; ok {
+			return value
+		}
+
+		return withPosition(newError("%s has no field %s", left.Struct.Name, name), node.Property.Token)
+	case *object.Map:
+		//	string keys are read before the methods, so config.port reads the "port" key
+		if value, ok := left.Get(&object.String{ Value: name }); ok {
+			return value
+		}
+	}
+
+	if method, ok := in.method(left, name); ok {
+		return method
+	}
+	//	a key missing from a map is null, the same as indexing it
+	if left.Type() == object.MAP_OBJECT {
+		return NULL
+	}
+
+	return withPosition(newError("%s has no method %s", left.Type(), name), node.Property.Token)
+}
@@ -0,0 +1,196 @@
+package evaluator
+
+import (
+	"language/ast"
+	"language/lexer"
+	"language/object"
+	"language/parser"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//	EvalFile evaluates a program read from the file at the given path,
+//	so the imports in it are looked up from the directory of the file
+func (in *Interpreter) EvalFile(path string, program *ast.Program, env *object.Environment) object.Object {
+	if absolute, err := filepath.Abs(path); err == nil {
+		path = absolute
+	}
+
+	in.files = append(in.files, path)
+	//	the top of an imported file is not inside the function that imports it
+	calls := in.calls
+	in.calls = 0
+	defer func() {
+		in.files = in.files[:len(in.files) - 1]
+		in.calls = calls
+	}()
+
+	return in.Eval(program, env)
+}
+
+//	insideSandbox checks if the file is in the directory of the program being run,
+//	or the working directory for programs that are not read from a file, like the REPL.
+//	Those are the only files a program can import when file system access is disabled
+func (in *Interpreter) insideSandbox(path string) bool {
+	root := "."
+	if len(in.files) > 0 {
+		root = filepath.Dir(in.files[0])
+	}
+	//	links are followed so they cannot point out of the directory
+	resolve := func(p string) string {
+		if resolved, err := filepath.EvalSymlinks(p); err == nil {
+			p = resolved
+		}
+		if absolute, err := filepath.Abs(p); err == nil {
+			p = absolute
+		}
+		return p
+	}
+
+	relative, err := filepath.Rel(resolve(root), resolve(path))
+	return err == nil && relative != ".." && !strings.HasPrefix(relative, ".." + string(filepath.Separator))
+}
+
+//	resolveModule finds the file an import points to. Relative paths are looked up
+//	next to the file doing the import first, and then in each of the search paths
+func (in *Interpreter) resolveModule(path string) (string, bool) {
+	candidates := []string{ path }
+	if !filepath.IsAbs(path) {
+		//	programs that are not read from a file, like the REPL, import from the working directory
+		dir := "."
+		if len(in.files) > 0 {
+			dir = filepath.Dir(in.files[len(in.files) - 1])
+		}
+
+		candidates = []string{ filepath.Join(dir, path) }
+		for _, searchPath := range in.Paths {
+			candidates = append(candidates, filepath.Join(searchPath, path))
+		}
+	}
+
+	for _, candidate := range candidates {
+		info, err := os.Stat(candidate)
+		if err != nil || info.IsDir() {
+			continue
+		}
+
+		if !in.AllowFileSystem && !in.insideSandbox(candidate) {
+			continue
+		}
+
+		if absolute, err := filepath.Abs(candidate); err == nil {
+			return absolute, true
+		}
+	}
+
+	return "", false
+}
+
+//	importCycle checks if the file is already being imported, which would import it forever
+func (in *Interpreter) importCycle(path string) *object.Error {
+	for i, file := range in.files {
+		if file != path {
+			continue
+		}
+
+		names := []string{}
+		for _, importing := range in.files[i:] {
+			names = append(names, filepath.Base(importing))
+		}
+		names = append(names, filepath.Base(path))
+
+		return newError("Import cycle: %s", strings.Join(names, " -> "))
+	}
+
+	return nil
+}
+
+//	exportedNames returns the names of the variables the program exports
+func exportedNames(program *ast.Program) []string {
+	names := []string{}
+	for _, statement := range program.Statements {
+		if export, ok := statement.(*ast.ExportStatement); ok {
+			names = append(names, export.Name.Value)
+		}
+	}
+
+	return names
+}
+
+//	loadModule runs the file in an environment of its own the first time it is imported,
+//	and gives back the same module every time after that
+func (in *Interpreter) loadModule(path string) object.Object {
+	if module, ok := in.modules[path]; ok {
+		return module
+	}
+
+	if err := in.importCycle(path); err != nil {
+		return err
+	}
+
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return ioError("import", path, err)
+	}
+
+	p := parser.New(lexer.New(string(source)))
+	program := p.ParserProgram()
+
+	if len(p.Errors()) != 0 {
+		//	the errors quote the source of the module, so only where the first one is gets reported
+		position := strings.TrimPrefix(strings.SplitN(p.Errors()[0], "]", 2)[0], "[")
+		return newError("Could not parse module %s at %s", filepath.Base(path), position)
+	}
+
+	env := object.NewEnvironment()
+	//	errors and exits in the module stop the program importing it
+	if result := in.EvalFile(path, program, env); isError(result) || result != nil && result.Type() == object.EXIT_OBJECT {
+		return result
+	}
+
+	module := &object.Module{ Path: path, Env: env, Exports: exportedNames(program) }
+	in.modules[path] = module
+
+	return module
+}
+
+func (in *Interpreter) evalImportStatement(node *ast.ImportStatement, env *object.Environment) object.Object {
+	path, ok := in.resolveModule(node.Path.Value)
+	if !ok && !in.AllowFileSystem {
+		return withPosition(
+			newError("Module not found: %s. File system access is disabled, so only files in the directory of the program can be imported", node.Path.Value),
+			node.Token,
+		)
+	}
+	if !ok {
+		return withPosition(newError("Module not found: %s", node.Path.Value), node.Token)
+	}
+
+	module := in.loadModule(path)
+
+	if err, ok := module.(*object.Error); ok {
+		//	errors raised while running the module keep their position in it,
+		//	and the import is added to their stack
+		if err.Line == 0 {
+			return withPosition(err, node.Token)
+		}
+
+		err.Stack = append(err.Stack, object.Frame{
+			Function: "module " + node.Path.Value,
+			Line: node.Token.Line,
+			Column: node.Token.Column,
+		})
+		return err
+	}
+
+	if isReturnOrError(module) {
+		return module
+	}
+
+	if bound := env.Set(node.Name.Value, module); isError(bound) {
+		return withPosition(bound, node.Name.Token)
+	}
+
+	return module
+}
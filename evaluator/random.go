@@ -0,0 +1,100 @@
+package evaluator
+
+import (
+	"language/object"
+)
+
+var randomBuiltins = map[string]*builtin{
+	"random": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 0 {
+				return newError("Wrong number of arguments. Got %d, expected 0", len(args))
+			}
+			//	a double between 0 and 1, without including 1
+			return &object.Double{ Value: in.random.Float64() }
+		},
+	},
+	"randInt": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 2 {
+				return newError("Wrong number of arguments. Got %d, expected 2", len(args))
+			}
+
+			low, ok := args[0].(*object.Integer)
+			if !ok {
+				return newError("First argument to `randInt` must be an Integer, got %s", args[0].Type())
+			}
+
+			high, ok := args[1].(*object.Integer)
+			if !ok {
+				return newError("Second argument to `randInt` must be an Integer, got %s", args[1].Type())
+			}
+
+			if low.Value > high.Value {
+				return newError("The lower bound passed to `randInt` is bigger than the upper bound")
+			}
+			//	both bounds are included
+			span := high.Value - low.Value + 1
+			if span <= 0 {
+				return newError("The range passed to `randInt` is too big")
+			}
+
+			return &object.Integer{ Value: low.Value + in.random.Int63n(span) }
+		},
+	},
+	"choice": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("Wrong number of arguments. Got %d, expected 1", len(args))
+			}
+
+			array, ok := args[0].(*object.Array)
+			if !ok {
+				return newError("Argument to `choice` must be an Array, got %s", args[0].Type())
+			}
+
+			if len(array.Elements) == 0 {
+				return newError("Cannot choose from an empty array")
+			}
+
+			return array.Elements[in.random.Intn(len(array.Elements))]
+		},
+	},
+	"shuffle": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("Wrong number of arguments. Got %d, expected 1", len(args))
+			}
+
+			array, ok := args[0].(*object.Array)
+			if !ok {
+				return newError("Argument to `shuffle` must be an Array, got %s", args[0].Type())
+			}
+			//	shuffles a copy, leaving the array passed untouched
+			shuffled := make([]object.Object, len(array.Elements))
+			copy(shuffled, array.Elements)
+
+			in.random.Shuffle(len(shuffled), func(i, j int) {
+				shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+			})
+
+			return &object.Array{ Elements: shuffled }
+		},
+	},
+	"seed": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("Wrong number of arguments. Got %d, expected 1", len(args))
+			}
+
+			seed, ok := args[0].(*object.Integer)
+			if !ok {
+				return newError("Argument to `seed` must be an Integer, got %s", args[0].Type())
+			}
+
+			in.Seed(seed.Value)
+
+			return NULL
+		},
+	},
+}
@@ -0,0 +1,91 @@
+package evaluator
+
+import (
+	"language/ast"
+	"language/object"
+)
+
+//	dataTypes are the types of the values each data type keyword accepts
+var dataTypes = map[string][]object.ObjectType{
+	"int": { object.INTEGER_OBJECT, object.BIGINT_OBJECT },
+	"double": { object.DOUBLE_OBJECT },
+	"string": { object.STRING_OBJECT },
+	"bool": { object.BOOLEAN_OBJECT },
+	"array": { object.ARRAY_OBJECT },
+	"map": { object.MAP_OBJECT },
+	"fn": { object.FUNCTION_OBJECT, object.BUILTIN_OBJECT },
+	"time": { object.TIME_OBJECT },
+}
+
+//	typeMatches checks if the value is of the type with the given name,
+//	which is a data type keyword or the name of a struct
+func typeMatches(typeName string, value object.Object) bool {
+	types, ok := dataTypes[typeName]
+	if !ok {
+		record, ok := value.(*object.Record)
+		return ok && record.Struct.Name == typeName
+	}
+
+	for _, t := range types {
+		if value.Type() == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (in *Interpreter) evalStructStatement(node *ast.StructStatement, env *object.Environment) object.Object {
+	structure := &object.Struct{ Name: node.Name.Value }
+	for _, field := range node.Fields {
+		structure.Fields = append(structure.Fields, object.StructField{ Name: field.Name.Value, Type: field.Type.Literal })
+	}
+
+	if bound := env.Set(node.Name.Value, structure); isError(bound) {
+		return withPosition(bound, node.Name.Token)
+	}
+
+	return structure
+}
+
+func (in *Interpreter) evalStructLiteral(node *ast.StructLiteral, env *object.Environment) object.Object {
+	definition := in.Eval(node.Struct, env)
+	if isReturnOrError(definition) {
+		return definition
+	}
+
+	structure, ok := definition.(*object.Struct)
+	if !ok {
+		return withPosition(newError("%s is not a struct, got %s", node.Struct.String(), definition.Type()), node.Token)
+	}
+
+	fields := make(map[string]object.Object)
+	for _, field := range node.Fields {
+		declared, ok := structure.Field(field.Name.Value)
+		if !ok {
+			return withPosition(newError("%s has no field %s", structure.Name, field.Name.Value), field.Name.Token)
+		}
+
+		value := in.Eval(field.Value, env)
+		if isReturnOrError(value) {
+			return value
+		}
+
+		if !typeMatches(declared.Type, value) {
+			return withPosition(
+				newError("Field %s of %s must be %s, got %s", declared.Name, structure.Name, declared.Type, object.TypeName(value)),
+				field.Name.Token,
+			)
+		}
+
+		fields[declared.Name] = value
+	}
+	//	every field has to be given a value
+	for _, declared := range structure.Fields {
+		if _, ok := fields[declared.Name]; !ok {
+			return withPosition(newError("Missing field %s of %s", declared.Name, structure.Name), node.Token)
+		}
+	}
+
+	return &object.Record{ Struct: structure, Fields: fields }
+}
@@ -0,0 +1,134 @@
+package evaluator
+
+import (
+	"language/object"
+	"regexp"
+)
+
+//	compileRegex compiles the pattern, reusing it if the interpreter already compiled it
+func (in *Interpreter) compileRegex(pattern string) object.Object {
+	if regex, ok := in.regexes[pattern]; ok {
+		return regex
+	}
+
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return newError("Invalid regular expression %q: %s", pattern, err)
+	}
+
+	regex := &object.Regex{ Pattern: pattern, Value: compiled }
+	in.regexes[pattern] = regex
+
+	return regex
+}
+
+//	regexArguments checks the arguments of the builtins that take a regex, or a pattern, and strings
+func (in *Interpreter) regexArguments(name string, args []object.Object, count int) (*regexp.Regexp, []string, *object.Error) {
+	if len(args) != count {
+		return nil, nil, newError("Wrong number of arguments. Got %d, expected %d", len(args), count)
+	}
+
+	var regex object.Object
+	switch arg := args[0].(type) {
+	case *object.Regex:
+		regex = arg
+	case *object.String:
+		regex = in.compileRegex(arg.Value)
+	default:
+		return nil, nil, newError("First argument to `%s` must be a Regex or a String, got %s", name, args[0].Type())
+	}
+
+	if err, ok := regex.(*object.Error); ok {
+		return nil, nil, err
+	}
+
+	values := make([]string, count - 1)
+	for i, arg := range args[1:] {
+		str, ok := arg.(*object.String)
+		if !ok {
+			return nil, nil, newError("%s argument to `%s` must be a String, got %s", ordinals[i + 1], name, arg.Type())
+		}
+
+		values[i] = str.Value
+	}
+
+	return regex.(*object.Regex).Value, values, nil
+}
+
+var regexBuiltins = map[string]*builtin{
+	"regex": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			values, err := stringArguments("regex", args, 1)
+			if err != nil {
+				return err
+			}
+
+			return in.compileRegex(values[0])
+		},
+	},
+	"match": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			regex, values, err := in.regexArguments("match", args, 2)
+			if err != nil {
+				return err
+			}
+
+			return nativeBoolToBooleaObject(regex.MatchString(values[0]))
+		},
+	},
+	"findAll": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			regex, values, err := in.regexArguments("findAll", args, 2)
+			if err != nil {
+				return err
+			}
+
+			return stringArray(regex.FindAllString(values[0], -1))
+		},
+	},
+	"replaceAll": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			regex, values, err := in.regexArguments("replaceAll", args, 3)
+			if err != nil {
+				return err
+			}
+			//	the replacement can use the groups of the match, like $1 or ${name}
+			return &object.String{ Value: regex.ReplaceAllString(values[0], values[1]) }
+		},
+	},
+	"captureGroups": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			regex, values, err := in.regexArguments("captureGroups", args, 2)
+			if err != nil {
+				return err
+			}
+
+			match := regex.FindStringSubmatchIndex(values[0])
+			if match == nil {
+				return NULL
+			}
+			//	named groups are keyed by their name and the rest by their number,
+			//	groups that did not take part in the match are null
+			groups := object.NewMap()
+			for i, name := range regex.SubexpNames() {
+				if i == 0 {
+					continue
+				}
+
+				var key object.Object = &object.Integer{ Value: int64(i) }
+				if name != "" {
+					key = &object.String{ Value: name }
+				}
+
+				var value object.Object = NULL
+				if match[2 * i] >= 0 {
+					value = &object.String{ Value: values[0][match[2 * i]:match[2 * i + 1]] }
+				}
+
+				groups.Set(key, value)
+			}
+
+			return groups
+		},
+	},
+}
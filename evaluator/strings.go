@@ -0,0 +1,300 @@
+package evaluator
+
+import (
+	"fmt"
+	"language/object"
+	"strings"
+	"unicode/utf8"
+)
+
+var ordinals = []string{ "First", "Second", "Third" }
+
+//	maxStringLength is the most bytes repeat and pad make a string of,
+//	so a huge count is an error and not a program that runs out of memory
+const maxStringLength = 1 << 28
+
+//	stringArguments checks that the builtin received the given number of strings and returns their values
+func stringArguments(name string, args []object.Object, count int) ([]string, *object.Error) {
+	if len(args) != count {
+		return nil, newError("Wrong number of arguments. Got %d, expected %d", len(args), count)
+	}
+
+	values := make([]string, count)
+	for i, arg := range args {
+		str, ok := arg.(*object.String)
+		if !ok {
+			return nil, newError("%s argument to `%s` must be a String, got %s", ordinals[i], name, arg.Type())
+		}
+
+		values[i] = str.Value
+	}
+
+	return values, nil
+}
+
+func stringArray(values []string) *object.Array {
+	elements := make([]object.Object, len(values))
+	for i, value := range values {
+		elements[i] = &object.String{ Value: value }
+	}
+
+	return &object.Array{ Elements: elements }
+}
+
+//	pad adds the padding character to the string until it is as long as the width
+func pad(name string, args []object.Object, left bool) object.Object {
+	if len(args) != 2 && len(args) != 3 {
+		return newError("Wrong number of arguments. Expected 2 or 3, got %d", len(args))
+	}
+
+	str, ok := args[0].(*object.String)
+	if !ok {
+		return newError("First argument to `%s` must be a String, got %s", name, args[0].Type())
+	}
+
+	width, ok := args[1].(*object.Integer)
+	if !ok {
+		return newError("Second argument to `%s` must be an Integer, got %s", name, args[1].Type())
+	}
+	//	pads with spaces if no character is passed
+	padding := " "
+	if len(args) == 3 {
+		char, ok := args[2].(*object.String)
+		if !ok || utf8.RuneCountInString(char.Value) != 1 {
+			return newError("Third argument to `%s` must be a single character", name)
+		}
+
+		padding = char.Value
+	}
+
+	missing := width.Value - int64(utf8.RuneCountInString(str.Value))
+	if missing <= 0 {
+		return str
+	}
+
+	if missing > int64(maxStringLength - len(str.Value)) / int64(len(padding)) {
+		return newError("Width passed to `%s` is too big: %d", name, width.Value)
+	}
+
+	if left {
+		return &object.String{ Value: strings.Repeat(padding, int(missing)) + str.Value }
+	}
+
+	return &object.String{ Value: str.Value + strings.Repeat(padding, int(missing)) }
+}
+
+//	formatValue turns an object into the Go value the printf verbs expect
+func formatValue(obj object.Object) interface{} {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return obj.Value
+	case *object.BigInt:
+		return obj.Value
+	case *object.Double:
+		return obj.Value
+	case *object.String:
+		return obj.Value
+	case *object.Boolean:
+		return obj.Value
+	default:
+		return obj.Inspect()
+	}
+}
+
+var stringBuiltins = map[string]*builtin{
+	"split": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			values, err := stringArguments("split", args, 2)
+			if err != nil {
+				return err
+			}
+
+			return stringArray(strings.Split(values[0], values[1]))
+		},
+	},
+	"join": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 2 {
+				return newError("Wrong number of arguments. Got %d, expected 2", len(args))
+			}
+
+			array, ok := args[0].(*object.Array)
+			if !ok {
+				return newError("First argument to `join` must be an Array, got %s", args[0].Type())
+			}
+
+			separator, ok := args[1].(*object.String)
+			if !ok {
+				return newError("Second argument to `join` must be a String, got %s", args[1].Type())
+			}
+
+			values := make([]string, len(array.Elements))
+			for i, element := range array.Elements {
+				str, ok := element.(*object.String)
+				if !ok {
+					return newError("Elements passed to `join` must be Strings, got %s", element.Type())
+				}
+
+				values[i] = str.Value
+			}
+
+			return &object.String{ Value: strings.Join(values, separator.Value) }
+		},
+	},
+	"trim": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			values, err := stringArguments("trim", args, 1)
+			if err != nil {
+				return err
+			}
+
+			return &object.String{ Value: strings.TrimSpace(values[0]) }
+		},
+	},
+	"upper": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			values, err := stringArguments("upper", args, 1)
+			if err != nil {
+				return err
+			}
+
+			return &object.String{ Value: strings.ToUpper(values[0]) }
+		},
+	},
+	"lower": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			values, err := stringArguments("lower", args, 1)
+			if err != nil {
+				return err
+			}
+
+			return &object.String{ Value: strings.ToLower(values[0]) }
+		},
+	},
+	"replace": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			values, err := stringArguments("replace", args, 3)
+			if err != nil {
+				return err
+			}
+			//	replaces every occurrence
+			return &object.String{ Value: strings.ReplaceAll(values[0], values[1], values[2]) }
+		},
+	},
+	"contains": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			values, err := stringArguments("contains", args, 2)
+			if err != nil {
+				return err
+			}
+
+			return nativeBoolToBooleaObject(strings.Contains(values[0], values[1]))
+		},
+	},
+	"startsWith": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			values, err := stringArguments("startsWith", args, 2)
+			if err != nil {
+				return err
+			}
+
+			return nativeBoolToBooleaObject(strings.HasPrefix(values[0], values[1]))
+		},
+	},
+	"endsWith": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			values, err := stringArguments("endsWith", args, 2)
+			if err != nil {
+				return err
+			}
+
+			return nativeBoolToBooleaObject(strings.HasSuffix(values[0], values[1]))
+		},
+	},
+	"indexOf": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			values, err := stringArguments("indexOf", args, 2)
+			if err != nil {
+				return err
+			}
+
+			idx := strings.Index(values[0], values[1])
+			if idx < 0 {
+				return &object.Integer{ Value: -1 }
+			}
+			//	the index is counted in characters, the same way strings are indexed
+			return &object.Integer{ Value: int64(utf8.RuneCountInString(values[0][:idx])) }
+		},
+	},
+	"repeat": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 2 {
+				return newError("Wrong number of arguments. Got %d, expected 2", len(args))
+			}
+
+			str, ok := args[0].(*object.String)
+			if !ok {
+				return newError("First argument to `repeat` must be a String, got %s", args[0].Type())
+			}
+
+			count, ok := args[1].(*object.Integer)
+			if !ok {
+				return newError("Second argument to `repeat` must be an Integer, got %s", args[1].Type())
+			}
+
+			if count.Value < 0 {
+				return newError("Cannot repeat a string a negative number of times: %d", count.Value)
+			}
+
+			if len(str.Value) > 0 && count.Value > int64(maxStringLength / len(str.Value)) {
+				return newError("Cannot repeat a string %d times, the result would be too long", count.Value)
+			}
+
+			return &object.String{ Value: strings.Repeat(str.Value, int(count.Value)) }
+		},
+	},
+	"padLeft": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			return pad("padLeft", args, true)
+		},
+	},
+	"padRight": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			return pad("padRight", args, false)
+		},
+	},
+	"chars": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			values, err := stringArguments("chars", args, 1)
+			if err != nil {
+				return err
+			}
+
+			chars := []string{}
+			for _, char := range values[0] {
+				chars = append(chars, string(char))
+			}
+
+			return stringArray(chars)
+		},
+	},
+	"format": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) == 0 {
+				return newError("Wrong number of arguments. Expected at least 1, got 0")
+			}
+
+			format, ok := args[0].(*object.String)
+			if !ok {
+				return newError("First argument to `format` must be a String, got %s", args[0].Type())
+			}
+
+			values := make([]interface{}, len(args) - 1)
+			for i, arg := range args[1:] {
+				values[i] = formatValue(arg)
+			}
+
+			return &object.String{ Value: fmt.Sprintf(format.Value, values...) }
+		},
+	},
+}
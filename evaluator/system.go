@@ -0,0 +1,96 @@
+package evaluator
+
+import (
+	"fmt"
+	"io"
+	"language/object"
+	"os"
+	"strings"
+)
+
+var systemBuiltins = map[string]*builtin{
+	"args": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 0 {
+				return newError("Wrong number of arguments. Got %d, expected 0", len(args))
+			}
+
+			return stringArray(in.Args)
+		},
+	},
+	"input": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) > 1 {
+				return newError("Wrong number of arguments. Expected 0 or 1, got %d", len(args))
+			}
+			//	the prompt is printed without a line break, so the answer goes next to it
+			if len(args) == 1 {
+				prompt, ok := args[0].(*object.String)
+				if !ok {
+					return newError("Argument to `input` must be a String, got %s", args[0].Type())
+				}
+
+				fmt.Fprint(in.Out, prompt.Value)
+			}
+
+			line, err := in.stdin().ReadString('\n')
+			if err != nil && err != io.EOF {
+				return &object.Error{ Message: fmt.Sprintf("Could not read the input: %s", err), Kind: IO_ERROR }
+			}
+			//	when there is nothing left to read, input returns null
+			if err == io.EOF && line == "" {
+				return NULL
+			}
+
+			line = strings.TrimSuffix(line, "\n")
+			return &object.String{ Value: strings.TrimSuffix(line, "\r") }
+		},
+	},
+	"readStdin": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 0 {
+				return newError("Wrong number of arguments. Got %d, expected 0", len(args))
+			}
+
+			content, err := io.ReadAll(in.stdin())
+			if err != nil {
+				return &object.Error{ Message: fmt.Sprintf("Could not read the input: %s", err), Kind: IO_ERROR }
+			}
+
+			return &object.String{ Value: string(content) }
+		},
+	},
+	"env": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			values, err := stringArguments("env", args, 1)
+			if err != nil {
+				return err
+			}
+			//	variables that are not set are null
+			value, ok := os.LookupEnv(values[0])
+			if !ok {
+				return NULL
+			}
+
+			return &object.String{ Value: value }
+		},
+	},
+	"exit": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) > 1 {
+				return newError("Wrong number of arguments. Expected 0 or 1, got %d", len(args))
+			}
+			//	the status code is 0 if none is passed
+			if len(args) == 0 {
+				return &object.Exit{ Code: 0 }
+			}
+
+			code, ok := args[0].(*object.Integer)
+			if !ok {
+				return newError("Argument to `exit` must be an Integer, got %s", args[0].Type())
+			}
+
+			return &object.Exit{ Code: code.Value }
+		},
+	},
+}
@@ -0,0 +1,142 @@
+package evaluator
+
+import (
+	"language/object"
+	"time"
+)
+
+//	evalTimeInfixExpression evaluates operations with times. Durations are integers of milliseconds:
+//	adding or subtracting them moves a time, and subtracting two times gives the duration between them
+func evalTimeInfixExpression(operator string, left, right object.Object) object.Object {
+	leftTime, leftIsTime := left.(*object.Time)
+	rightTime, rightIsTime := right.(*object.Time)
+
+	switch {
+	case leftIsTime && rightIsTime:
+		switch operator {
+		case "-":
+			return &object.Integer{ Value: leftTime.Value.Sub(rightTime.Value).Milliseconds() }
+		case "==":
+			return nativeBoolToBooleaObject(leftTime.Value.Equal(rightTime.Value))
+		case "!=":
+			return nativeBoolToBooleaObject(!leftTime.Value.Equal(rightTime.Value))
+		case "<":
+			return nativeBoolToBooleaObject(leftTime.Value.Before(rightTime.Value))
+		case ">":
+			return nativeBoolToBooleaObject(leftTime.Value.After(rightTime.Value))
+		case "<=":
+			return nativeBoolToBooleaObject(!leftTime.Value.After(rightTime.Value))
+		case ">=":
+			return nativeBoolToBooleaObject(!leftTime.Value.Before(rightTime.Value))
+		}
+	case leftIsTime && right.Type() == object.INTEGER_OBJECT:
+		duration := time.Duration(right.(*object.Integer).Value) * time.Millisecond
+		switch operator {
+		case "+":
+			return &object.Time{ Value: leftTime.Value.Add(duration) }
+		case "-":
+			return &object.Time{ Value: leftTime.Value.Add(-duration) }
+		}
+	case rightIsTime && left.Type() == object.INTEGER_OBJECT && operator == "+":
+		duration := time.Duration(left.(*object.Integer).Value) * time.Millisecond
+		return &object.Time{ Value: rightTime.Value.Add(duration) }
+	}
+
+	switch operator {
+	case "==":
+		return FALSE
+	case "!=":
+		return TRUE
+	}
+
+	return newError("Unknown operator: %s %s %s", left.Type(), operator, right.Type())
+}
+
+//	timeLayout returns the layout passed to formatTime or parseTime, which is RFC 3339 by default
+func timeLayout(name string, args []object.Object) (string, *object.Error) {
+	if len(args) < 2 {
+		return time.RFC3339, nil
+	}
+
+	layout, ok := args[1].(*object.String)
+	if !ok {
+		return "", newError("Second argument to `%s` must be a String, got %s", name, args[1].Type())
+	}
+
+	return layout.Value, nil
+}
+
+var timeBuiltins = map[string]*builtin{
+	"now": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 0 {
+				return newError("Wrong number of arguments. Got %d, expected 0", len(args))
+			}
+
+			return &object.Time{ Value: in.Clock() }
+		},
+	},
+	"formatTime": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 1 && len(args) != 2 {
+				return newError("Wrong number of arguments. Expected 1 or 2, got %d", len(args))
+			}
+
+			t, ok := args[0].(*object.Time)
+			if !ok {
+				return newError("First argument to `formatTime` must be a Time, got %s", args[0].Type())
+			}
+			//	layouts are written with Go's reference time, Mon Jan 2 15:04:05 MST 2006
+			layout, err := timeLayout("formatTime", args)
+			if err != nil {
+				return err
+			}
+
+			return &object.String{ Value: t.Value.Format(layout) }
+		},
+	},
+	"parseTime": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 1 && len(args) != 2 {
+				return newError("Wrong number of arguments. Expected 1 or 2, got %d", len(args))
+			}
+
+			str, ok := args[0].(*object.String)
+			if !ok {
+				return newError("First argument to `parseTime` must be a String, got %s", args[0].Type())
+			}
+
+			layout, err := timeLayout("parseTime", args)
+			if err != nil {
+				return err
+			}
+
+			t, parseErr := time.Parse(layout, str.Value)
+			if parseErr != nil {
+				return newError("Could not parse %q as a time with the layout %q", str.Value, layout)
+			}
+
+			return &object.Time{ Value: t }
+		},
+	},
+	"sleep": {
+		Fn: func(in *Interpreter, args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("Wrong number of arguments. Got %d, expected 1", len(args))
+			}
+
+			ms, ok := args[0].(*object.Integer)
+			if !ok {
+				return newError("Argument to `sleep` must be an Integer, got %s", args[0].Type())
+			}
+
+			if ms.Value < 0 {
+				return newError("Cannot sleep a negative number of milliseconds: %d", ms.Value)
+			}
+
+			in.Sleep(time.Duration(ms.Value) * time.Millisecond)
+
+			return NULL
+		},
+	},
+}
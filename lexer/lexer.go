@@ -2,6 +2,7 @@ package lexer
 
 import (
 	"language/token"
+	"strings"
 )
 
 type Lexer struct {
@@ -9,11 +10,13 @@ type Lexer struct {
 	position int //	current position in input, points t current char
 	readPosition int //	current reading position in input, after current char
 	ch byte //	current char under examination
+	line int //	line of the current char
+	column int //	column of the current char
 }
 
 func New(input string) *Lexer {
 	//	assigns the Lexer memory address value to the l variable
-	l := &Lexer{ input: input }
+	l := &Lexer{ input: input, line: 1 }
 	//	Reads the first character of the input
 	l.readChar()
 	//	returns the new Lexer value
@@ -21,6 +24,11 @@ func New(input string) *Lexer {
 }
 
 func (l *Lexer) readChar() {
+	//	if the character being left behind is a new line, the next one starts a new line
+	if l.ch == '\n' {
+		l.line += 1
+		l.column = 0
+	}
 	//	If the position of the next character is the end or after the end of the input
 	if l.readPosition >= len(l.input) {
 		//	set the character under examination as 0
@@ -33,6 +41,8 @@ func (l *Lexer) readChar() {
 	l.position = l.readPosition
 	//	advances the next position stored in the Lexer to the next one
 	l.readPosition += 1
+	//	advances the column of the current character
+	l.column += 1
 }
 
 func newToken(tokenType token.TokenType, ch byte) token.Token {
@@ -50,13 +60,14 @@ func isLetter(ch byte) bool {
 func (l *Lexer) readIdentifier() string {
 	//	assigns the current position to a variable to reference it later
 	position := l.position
-	for isLetter(l.ch) {
+	for isLetter(l.ch) || isDigit(l.ch) {
 		//	acts like a while loop
-		//	while the character is a letter, read the character
+		//	while the character is a letter or a digit, read the character
+		//	identifiers start with a letter, so digits are only read after the first one
 		l.readChar()
 	}
 
-	//	returns the input on the next non-letter position
+	//	returns the input on the next position that is not part of the identifier
 	return l.input[position:l.position]
 }
 
@@ -98,15 +109,35 @@ func (l *Lexer) peekChar() byte {
 	}
 }
 
+//	escape sequences that can be written inside strings,
+//	any other backslash is kept as it is
+var escapes = map[byte]byte{
+	'"': '"',
+	'\\': '\\',
+	'n': '\n',
+	't': '\t',
+	'r': '\r',
+}
+
 func (l *Lexer) readString() string {
-	position := l.position + 1
+	var out strings.Builder
 	for {
 		l.readChar()
 		if l.ch == '"' || l.ch == 0 {
 			break
 		}
+
+		if l.ch == '\\' {
+			if escaped, ok := escapes[l.peekChar()]; ok {
+				l.readChar()
+				out.WriteByte(escaped)
+				continue
+			}
+		}
+
+		out.WriteByte(l.ch)
 	}
-	return l.input[position:l.position]
+	return out.String()
 }
 
 func (l *Lexer) skipComment() {
@@ -114,7 +145,8 @@ func (l *Lexer) skipComment() {
 	l.readChar()
 	l.readChar()
 	//	loops until the next # is found (marking the end of the comment)
-	for l.ch != '#' {
+	//	or the end of the input if the comment is never closed
+	for l.ch != '#' && l.ch != 0 {
 		l.readChar()
 	}
 	//	goes to the next token to skip the last #
@@ -129,6 +161,12 @@ func (l *Lexer) NextToken() token.Token {
 
 	//	skips the whitespace
 	l.skipWhitespace()
+	//	skips any comments before the token
+	for l.ch == '/' && l.peekChar() == '#' {
+		l.skipComment()
+	}
+	//	stores where the token starts so the parser can report it
+	line, column := l.line, l.column
 
 	//	switches according to the character encountered
 	switch l.ch {
@@ -153,19 +191,19 @@ func (l *Lexer) NextToken() token.Token {
 	case '*':
 		tok = newToken(token.MULTIPLY, l.ch)
 	case '/':
-		if l.peekChar() == '#' {
-			l.skipComment()
-			return l.NextToken()
-		}
 		tok = newToken(token.DIVIDE, l.ch)
 	case '%':
 		tok = newToken(token.MODULO, l.ch)
+	case '?':
+		tok = newToken(token.QUESTION, l.ch)
 	case ',':
 		tok = newToken(token.COMMA, l.ch)
 	case ';':
 		tok = newToken(token.SEMICOLON, l.ch)
 	case ':':
 		tok = newToken(token.COLON, l.ch)
+	case '.':
+		tok = newToken(token.DOT, l.ch)
 	case '(':
 		tok = newToken(token.L_PAREN, l.ch)
 	case ')':
@@ -255,14 +293,22 @@ func (l *Lexer) NextToken() token.Token {
 			tok.Literal = l.readIdentifier()
 			//	the type is looked in the LookupIdent function of the token file
 			tok.Type = token.LookupIdent(tok.Literal)
+			tok.Keyword = tok.Type != token.IDENTIFIER
+			tok.Line, tok.Column = line, column
 			//	returns the token
 			return tok
 		} else if isDigit(l.ch) {
 			//	if the token is a number it assigns an INT token type
-			//	TODO CHECK FOR DOUBLES
 			tok.Type = token.INT
 			//	reads the number and assigns it as the literal
 			tok.Literal = l.readNumber()
+			//	a number with a decimal part is a DOUBLE, like 1.5
+			if l.ch == '.' && isDigit(l.peekChar()) {
+				l.readChar()
+				tok.Type = token.DOUBLE
+				tok.Literal += "." + l.readNumber()
+			}
+			tok.Line, tok.Column = line, column
 			//	returns the token
 			return tok
 		} else {
@@ -271,6 +317,7 @@ func (l *Lexer) NextToken() token.Token {
 		}
 	}
 
+	tok.Line, tok.Column = line, column
 	//	advances position and return the token
 	l.readChar()
 	return tok
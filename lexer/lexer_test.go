@@ -157,3 +157,130 @@ func TestNextToken(t *testing.T) {
 		}
 	}
 }
+
+func TestTokenPositions(t *testing.T) {
+	input := "var int x = 5;\n/# comment #/  x = x + 10;"
+
+	tests := []struct{
+		expectedLiteral string
+		expectedLine int
+		expectedColumn int
+	}{
+		{"var", 1, 1},
+		{"int", 1, 5},
+		{"x", 1, 9},
+		{"=", 1, 11},
+		{"5", 1, 13},
+		{";", 1, 14},
+		{"/", 2, 13},
+		{"x", 2, 16},
+		{"=", 2, 18},
+		{"x", 2, 20},
+		{"+", 2, 22},
+		{"10", 2, 24},
+		{";", 2, 26},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. Expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+
+		if tok.Line != tt.expectedLine || tok.Column != tt.expectedColumn {
+			t.Fatalf(
+				"tests[%d] - position wrong for %q. Expected=%d:%d, got=%d:%d",
+				i,
+				tok.Literal,
+				tt.expectedLine,
+				tt.expectedColumn,
+				tok.Line,
+				tok.Column,
+			)
+		}
+	}
+}
+
+func TestUnterminatedComment(t *testing.T) {
+	l := New("5 /# never closed")
+
+	if tok := l.NextToken(); tok.Type != token.INT {
+		t.Fatalf("Expected INT, got %q", tok.Type)
+	}
+
+	if tok := l.NextToken(); tok.Type != token.EOF {
+		t.Fatalf("Expected EOF after an unterminated comment, got %q", tok.Type)
+	}
+}
+
+func TestDoubleLiterals(t *testing.T) {
+	tests := []struct{
+		expectedType token.TokenType
+		expectedLiteral string
+	}{
+		{token.DOUBLE, "3.14"},
+		{token.MINUS, "-"},
+		{token.DOUBLE, "0.5"},
+		{token.INT, "10"},
+		{token.SEMICOLON, ";"},
+		{token.EOF, ""},
+	}
+
+	l := New("3.14 -0.5 10;")
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - expected %q (%q), got %q (%q)", i, tt.expectedType, tt.expectedLiteral, tok.Type, tok.Literal)
+		}
+	}
+}
+
+func TestStringEscapes(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`"say \"hi\""`, `say "hi"`},
+		{`"a\nb\tc\r"`, "a\nb\tc\r"},
+		{`"back\\slash"`, `back\slash`},
+		{`"C:\dir"`, `C:\dir`},
+	}
+
+	for _, tt := range tests {
+		tok := New(tt.input).NextToken()
+
+		if tok.Type != token.STRING || tok.Literal != tt.expected {
+			t.Errorf("%s: expected STRING %q, got %q (%q)", tt.input, tt.expected, tok.Type, tok.Literal)
+		}
+	}
+}
+
+func TestIdentifiersWithDigits(t *testing.T) {
+	tests := []struct{
+		expectedType token.TokenType
+		expectedLiteral string
+	}{
+		{token.IDENTIFIER, "sha256"},
+		{token.L_PAREN, "("},
+		{token.IDENTIFIER, "x1"},
+		{token.R_PAREN, ")"},
+		{token.INT, "2"},
+		{token.IDENTIFIER, "y"},
+		{token.EOF, ""},
+	}
+
+	l := New("sha256(x1) 2y")
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - expected %q (%q), got %q (%q)", i, tt.expectedType, tt.expectedLiteral, tok.Type, tok.Literal)
+		}
+	}
+}
@@ -1,11 +1,14 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"language/evaluator"
 	"language/repl"
 	"language/runfile"
 	"os"
 	"os/user"
+	"path/filepath"
 )
 
 const LANGUAGE_NAME = "Simpl"
@@ -26,6 +29,11 @@ const LANGUAGE_NAME = "Simpl"
 // }
 
 func main() {
+	strictIndexing := flag.Bool("strict-indexing", false, "make indexing out of range an error instead of null")
+	sandbox := flag.Bool("sandbox", false, "run without access to the file system")
+	paths := flag.String("path", "", "directories to look for imported modules in, separated by "+string(os.PathListSeparator))
+	flag.Parse()
+
 	user, err := user.Current()
 
 	if err != nil {
@@ -34,17 +42,25 @@ func main() {
 
 	fmt.Printf("Welcome to %s, %s\n", LANGUAGE_NAME, user.Name)
 
-	if len(os.Args) > 1 {
-		fileName := os.Args[1]
-		err := runfile.ExecuteFile(fileName)
+	interpreter := evaluator.New()
+	interpreter.StrictIndexing = *strictIndexing
+	interpreter.AllowFileSystem = !*sandbox
+	interpreter.Paths = filepath.SplitList(*paths)
+
+	if flag.NArg() > 0 {
+		fileName := flag.Arg(0)
+		//	the arguments after the file name are passed to the program
+		interpreter.Args = flag.Args()[1:]
+		code, err := runfile.ExecuteFile(fileName, interpreter)
 
 		if err != nil {
-			fmt.Printf("Error executing file %s: %s\n", fileName, err)
+			fmt.Fprintf(interpreter.ErrOut, "Error executing file %s: %s\n", fileName, err)
 		}
 
+		os.Exit(code)
 	} else {
 		fmt.Println("No file provided. Starting REPL...")
 		fmt.Println("Write your code below:")
-		repl.Start(os.Stdin, os.Stdout)
+		repl.Start(os.Stdin, os.Stdout, interpreter)
 	}
 }
@@ -1,8 +1,8 @@
 package object
 
 import (
-	"bytes"
-	"strings"
+	"encoding/binary"
+	"hash/fnv"
 )
 
 type Array struct {
@@ -10,18 +10,26 @@ type Array struct {
 }
 
 func (a *Array) Type() ObjectType { return ARRAY_OBJECT }
-func (a *Array) Inspect() string {
-	var out bytes.Buffer
+func (a *Array) Inspect() string { return inspect(a, make(map[Object]bool)) }
 
-	elements := []string{}
+//	MapKey combines the keys of the elements, so arrays can be used as composite keys
+//	as long as all of their elements are Hashable
+func (a *Array) MapKey() MapKey {
+	h := fnv.New64a()
+	buffer := make([]byte, 8)
 
 	for _, el := range a.Elements {
-		elements = append(elements, el.Inspect())
-	}
+		mapable, ok := el.(Mapable)
+		if !ok {
+			h.Write([]byte(el.Type()))
+			continue
+		}
 
-	out.WriteString("[")
-	out.WriteString(strings.Join(elements, ", "))
-	out.WriteString("]")
+		key := mapable.MapKey()
+		binary.LittleEndian.PutUint64(buffer, key.Value)
+		h.Write([]byte(key.Type))
+		h.Write(buffer)
+	}
 
-	return out.String()
+	return MapKey{ Type: a.Type(), Value: h.Sum64() }
 }
@@ -0,0 +1,31 @@
+package object
+
+import (
+	"hash/fnv"
+	"math/big"
+)
+
+//	BigInt holds the integers that do not fit in an Integer
+type BigInt struct {
+	Value *big.Int
+}
+
+func (bi *BigInt) Type() ObjectType { return BIGINT_OBJECT }
+func (bi *BigInt) Inspect() string { return bi.Value.String() }
+
+func (bi *BigInt) MapKey() MapKey {
+	//	values that fit in an Integer use the same key as the equal Integer
+	if bi.Value.IsInt64() {
+		return (&Integer{ Value: bi.Value.Int64() }).MapKey()
+	}
+
+	h := fnv.New64a()
+
+	h.Write(bi.Value.Bytes())
+	//	the sign is not part of the bytes, so it is added to tell x and -x apart
+	if bi.Value.Sign() < 0 {
+		h.Write([]byte{'-'})
+	}
+
+	return MapKey{ Type: bi.Type(), Value: h.Sum64() }
+}
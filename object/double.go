@@ -0,0 +1,43 @@
+package object
+
+import (
+	"hash/fnv"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+//	Double holds floating point numbers
+type Double struct {
+	Value float64
+}
+
+func (d *Double) Type() ObjectType { return DOUBLE_OBJECT }
+func (d *Double) Inspect() string {
+	out := strconv.FormatFloat(d.Value, 'g', -1, 64)
+	//	whole doubles keep their decimal point, so they are not mistaken for integers
+	if !strings.ContainsAny(out, ".eIN") {
+		out += ".0"
+	}
+
+	return out
+}
+
+func (d *Double) MapKey() MapKey {
+	//	whole doubles use the same key as the equal integer, so 1.0 and 1 are the same key
+	if d.Value == math.Trunc(d.Value) && !math.IsInf(d.Value, 0) {
+		if d.Value >= math.MinInt64 && d.Value < math.MaxInt64 {
+			return (&Integer{ Value: int64(d.Value) }).MapKey()
+		}
+
+		value, _ := big.NewFloat(d.Value).Int(nil)
+		return (&BigInt{ Value: value }).MapKey()
+	}
+
+	h := fnv.New64a()
+
+	h.Write([]byte(strconv.FormatFloat(d.Value, 'g', -1, 64)))
+
+	return MapKey{ Type: d.Type(), Value: h.Sum64() }
+}
@@ -5,6 +5,7 @@ import "fmt"
 type Environment struct {
 	store map[string]Object
 	outer *Environment
+	block bool //	the scope of a block inside a function, like a catch block
 }
 
 func NewEnclosedEnvironment(outer *Environment) *Environment {
@@ -13,6 +14,14 @@ func NewEnclosedEnvironment(outer *Environment) *Environment {
 	return env
 }
 
+//	NewBlockEnvironment makes the scope of a block. The variables declared in it
+//	stay in it, and reassigning the others changes them in the scope around it
+func NewBlockEnvironment(outer *Environment) *Environment {
+	env := NewEnclosedEnvironment(outer)
+	env.block = true
+	return env
+}
+
 func NewEnvironment() *Environment {
 	s := make(map[string]Object)
 	return &Environment{ store: s, outer: nil }
@@ -26,15 +35,37 @@ func (e *Environment) Get(name string) (Object, bool) {
 	return obj, ok
 }
 
+func compatibleTypes(previous, next Object) bool {
+	//	integers become big integers when they grow, and back when they shrink
+	isInteger := func(t ObjectType) bool { return t == INTEGER_OBJECT || t == BIGINT_OBJECT }
+	//	records of different structs cannot be assigned to each other
+	if previous, ok := previous.(*Record); ok {
+		next, ok := next.(*Record)
+		return ok && previous.Struct.Name == next.Struct.Name
+	}
+
+	return previous.Type() == next.Type() || isInteger(previous.Type()) && isInteger(next.Type())
+}
+
 func (e *Environment) Set(name string, value Object) Object {
 	prevValue, ok := e.store[name]
 
 	if ok {
-		if prevValue.Type() != value.Type() {
-			return &Error{ Message: fmt.Sprintf("Cannot reassign different types. Passed %s type to %s type variable", value.Type(), prevValue.Type()) }
+		if !compatibleTypes(prevValue, value) {
+			return &Error{ Message: fmt.Sprintf("Cannot reassign different types. Passed %s type to %s type variable", TypeName(value), TypeName(prevValue)) }
 		}
 	}
 
 	e.store[name] = value
 	return value
 }
+
+//	Scope returns the environment where reassigning the variable sets it.
+//	Blocks hand the variables they did not declare to the scope around them
+func (e *Environment) Scope(name string) *Environment {
+	if _, ok := e.store[name]; ok || !e.block {
+		return e
+	}
+
+	return e.outer.Scope(name)
+}
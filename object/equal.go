@@ -0,0 +1,116 @@
+package object
+
+import (
+	"math"
+	"math/big"
+)
+
+//	comparison is a pair of objects being compared, used to stop on cycles
+type comparison struct {
+	left Object
+	right Object
+}
+
+//	Equal compares two objects by value, going into the elements of arrays and maps
+func Equal(left, right Object) bool {
+	return equal(left, right, make(map[comparison]bool))
+}
+
+func equal(left, right Object, seen map[comparison]bool) bool {
+	switch left := left.(type) {
+	case *Integer:
+		switch right := right.(type) {
+		case *Integer:
+			return left.Value == right.Value
+		case *BigInt:
+			return right.Value.Cmp(big.NewInt(left.Value)) == 0
+		case *Double:
+			return float64(left.Value) == right.Value
+		}
+		return false
+	case *BigInt:
+		switch right := right.(type) {
+		case *Integer:
+			return left.Value.Cmp(big.NewInt(right.Value)) == 0
+		case *BigInt:
+			return left.Value.Cmp(right.Value) == 0
+		case *Double:
+			if math.IsNaN(right.Value) {
+				return false
+			}
+			return new(big.Float).SetInt(left.Value).Cmp(big.NewFloat(right.Value)) == 0
+		}
+		return false
+	case *Double:
+		switch right := right.(type) {
+		case *Double:
+			return left.Value == right.Value
+		case *Integer, *BigInt:
+			//	integers know how to compare themselves with doubles
+			return equal(right, left, seen)
+		}
+		return false
+	case *Time:
+		right, ok := right.(*Time)
+		return ok && left.Value.Equal(right.Value)
+	case *String:
+		right, ok := right.(*String)
+		return ok && left.Value == right.Value
+	case *Boolean:
+		right, ok := right.(*Boolean)
+		return ok && left.Value == right.Value
+	case *Null:
+		_, ok := right.(*Null)
+		return ok
+	case *Array:
+		right, ok := right.(*Array)
+		if !ok || len(left.Elements) != len(right.Elements) {
+			return false
+		}
+		//	a comparison already in progress is taken as equal, so cycles end
+		if seen[comparison{ left, right }] {
+			return true
+		}
+		seen[comparison{ left, right }] = true
+
+		for i := range left.Elements {
+			if !equal(left.Elements[i], right.Elements[i], seen) {
+				return false
+			}
+		}
+		return true
+	case *Map:
+		right, ok := right.(*Map)
+		if !ok || left.Len() != right.Len() {
+			return false
+		}
+
+		if seen[comparison{ left, right }] {
+			return true
+		}
+		seen[comparison{ left, right }] = true
+		//	the order of the keys does not matter
+		for _, pair := range left.Pairs() {
+			value, ok := right.Get(pair.Key)
+			if !ok || !equal(pair.Value, value, seen) {
+				return false
+			}
+		}
+		return true
+	case *Record:
+		//	records are equal when they are of the same struct and their fields are equal
+		right, ok := right.(*Record)
+		if !ok || left.Struct != right.Struct {
+			return false
+		}
+
+		for _, field := range left.Struct.Fields {
+			if !equal(left.Fields[field.Name], right.Fields[field.Name], seen) {
+				return false
+			}
+		}
+		return true
+	default:
+		return left == right
+	}
+}
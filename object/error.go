@@ -0,0 +1,61 @@
+package object
+
+import (
+	"bytes"
+	"fmt"
+)
+
+//	Frame is a function call an error went through while unwinding
+type Frame struct {
+	Function string //	name of the function called
+	Line int //	position of the call
+	Column int
+}
+
+type Error struct {
+	Message string
+	Kind string //	kind of error given when thrown, empty for runtime errors
+	Line int //	position where the error was raised, 0 if unknown
+	Column int
+	Stack []Frame //	calls the error went through, from the innermost to the outermost
+}
+
+func (e *Error) Type() ObjectType { return ERROR_OBJECT }
+func (e *Error) Inspect() string {
+	if e.Kind != "" {
+		return e.Kind + ": " + e.Message
+	}
+
+	return "Error: " + e.Message
+}
+
+//	Traceback returns the error message followed by where it was raised
+//	and every function call it went through
+func (e *Error) Traceback() string {
+	var out bytes.Buffer
+
+	out.WriteString(e.Inspect())
+
+	if e.Line > 0 {
+		out.WriteString(fmt.Sprintf("\n    at line %d, column %d", e.Line, e.Column))
+	}
+
+	for i := 0; i < len(e.Stack); i++ {
+		frame := e.Stack[i]
+		out.WriteString(fmt.Sprintf(
+			"\n    in %s, called at line %d, column %d", frame.Function, frame.Line, frame.Column,
+		))
+		//	collapses the frames repeated by recursive calls
+		repeated := 0
+		for i+1 < len(e.Stack) && e.Stack[i+1] == frame {
+			repeated += 1
+			i += 1
+		}
+
+		if repeated > 0 {
+			out.WriteString(fmt.Sprintf("\n    ... repeated %d more times", repeated))
+		}
+	}
+
+	return out.String()
+}
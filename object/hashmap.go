@@ -1,32 +1,102 @@
 package object
 
-import (
-	"bytes"
-	"fmt"
-	"strings"
-)
-
 type MapPair struct {
 	Key Object
 	Value Object
 }
 
+//	Map keeps its pairs in the order their keys were first inserted.
+//	Keys are found by their MapKey and then compared by value,
+//	so keys with colliding MapKeys do not overwrite each other
 type Map struct {
-	Pairs map[MapKey]MapPair
+	entries []MapPair //	pairs in insertion order
+	index map[MapKey][]int //	positions in entries of the pairs with each MapKey
+	isError bool //	the map holds an error, which maps made by programs never do
 }
 
-func (m *Map) Type() ObjectType { return MAP_OBJECT }
-func (m *Map) Inspect() string {
-	var out bytes.Buffer
+func NewMap() *Map {
+	return &Map{ index: make(map[MapKey][]int) }
+}
+
+//	NewErrorMap makes a map that holds an error, like the ones caught by a catch block.
+//	Only these maps are errors, whatever keys other maps have
+func NewErrorMap() *Map {
+	m := NewMap()
+	m.isError = true
+	return m
+}
+
+//	IsError checks if the map was made by NewErrorMap
+func (m *Map) IsError() bool { return m.isError }
 
-	pairs := []string{}
-	for _, pair := range m.Pairs {
-		pairs = append(pairs, fmt.Sprintf("%s: %s", pair.Key.Inspect(), pair.Value.Inspect()))
+//	find returns the position of the pair with the given key, or -1 if it is not there
+func (m *Map) find(key Object, mapKey MapKey) int {
+	for _, position := range m.index[mapKey] {
+		if Equal(m.entries[position].Key, key) {
+			return position
+		}
 	}
 
-	out.WriteString("{")
-	out.WriteString(strings.Join(pairs, ", "))
-	out.WriteString("}")
+	return -1
+}
+
+//	Set adds or replaces the value of a key, which has to be Hashable.
+//	Replacing a value keeps the key in its original position
+func (m *Map) Set(key, value Object) {
+	mapKey := key.(Mapable).MapKey()
+
+	if position := m.find(key, mapKey); position != -1 {
+		m.entries[position].Value = value
+		return
+	}
+	//	the key is copied so changing an array used as a key does not change the map
+	m.index[mapKey] = append(m.index[mapKey], len(m.entries))
+	m.entries = append(m.entries, MapPair{ Key: freezeKey(key), Value: value })
+}
+
+func (m *Map) Get(key Object) (Object, bool) {
+	if !Hashable(key) {
+		return nil, false
+	}
+
+	position := m.find(key, key.(Mapable).MapKey())
+	if position == -1 {
+		return nil, false
+	}
 
-	return out.String()
+	return m.entries[position].Value, true
 }
+
+func (m *Map) Delete(key Object) bool {
+	if !Hashable(key) {
+		return false
+	}
+
+	position := m.find(key, key.(Mapable).MapKey())
+	if position == -1 {
+		return false
+	}
+
+	m.entries = append(m.entries[:position], m.entries[position+1:]...)
+	//	the positions after the deleted pair moved, so the index is made again
+	m.index = make(map[MapKey][]int)
+	for i, pair := range m.entries {
+		mapKey := pair.Key.(Mapable).MapKey()
+		m.index[mapKey] = append(m.index[mapKey], i)
+	}
+
+	return true
+}
+
+func (m *Map) Len() int { return len(m.entries) }
+
+//	Pairs returns the pairs of the map in insertion order
+func (m *Map) Pairs() []MapPair {
+	pairs := make([]MapPair, len(m.entries))
+	copy(pairs, m.entries)
+
+	return pairs
+}
+
+func (m *Map) Type() ObjectType { return MAP_OBJECT }
+func (m *Map) Inspect() string { return inspect(m, make(map[Object]bool)) }
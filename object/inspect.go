@@ -0,0 +1,54 @@
+package object
+
+import (
+	"fmt"
+	"strings"
+)
+
+//	inspect prints the object, going into the elements of arrays, maps and records.
+//	The ones already being printed hold themselves, so they are printed as a placeholder
+func inspect(obj Object, visiting map[Object]bool) string {
+	switch obj := obj.(type) {
+	case *Array:
+		if visiting[obj] {
+			return "[...]"
+		}
+		visiting[obj] = true
+		defer delete(visiting, obj)
+
+		elements := []string{}
+		for _, el := range obj.Elements {
+			elements = append(elements, inspect(el, visiting))
+		}
+
+		return "[" + strings.Join(elements, ", ") + "]"
+	case *Map:
+		if visiting[obj] {
+			return "{...}"
+		}
+		visiting[obj] = true
+		defer delete(visiting, obj)
+
+		pairs := []string{}
+		for _, pair := range obj.Pairs() {
+			pairs = append(pairs, fmt.Sprintf("%s: %s", inspect(pair.Key, visiting), inspect(pair.Value, visiting)))
+		}
+
+		return "{" + strings.Join(pairs, ", ") + "}"
+	case *Record:
+		if visiting[obj] {
+			return obj.Struct.Name + "{...}"
+		}
+		visiting[obj] = true
+		defer delete(visiting, obj)
+
+		fields := []string{}
+		for _, field := range obj.Struct.Fields {
+			fields = append(fields, field.Name + ": " + inspect(obj.Fields[field.Name], visiting))
+		}
+
+		return obj.Struct.Name + "{" + strings.Join(fields, ", ") + "}"
+	default:
+		return obj.Inspect()
+	}
+}
@@ -0,0 +1,23 @@
+package object
+
+//	Module is a file loaded by an import. Its exports are read from the
+//	environment it ran in when they are used
+type Module struct {
+	Path string //	absolute path of the file
+	Env *Environment
+	Exports []string //	names of the exported variables, in the order they were declared
+}
+
+func (m *Module) Type() ObjectType { return MODULE_OBJECT }
+func (m *Module) Inspect() string { return "module(" + m.Path + ")" }
+
+//	Get returns the value of an exported variable
+func (m *Module) Get(name string) (Object, bool) {
+	for _, export := range m.Exports {
+		if export == name {
+			return m.Env.Get(name)
+		}
+	}
+
+	return nil, false
+}
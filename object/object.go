@@ -2,6 +2,7 @@ package object
 
 import (
 	"bytes"
+	"fmt"
 	"language/ast"
 	"strings"
 )
@@ -17,8 +18,54 @@ type Mapable interface {
 	MapKey() MapKey
 }
 
+//	Hashable reports if obj can be used as a map key.
+//	Arrays can only be keys when all of their elements can
+func Hashable(obj Object) bool {
+	return hashable(obj, make(map[Object]bool))
+}
+
+func hashable(obj Object, visiting map[Object]bool) bool {
+	if _, ok := obj.(Mapable); !ok {
+		return false
+	}
+
+	if array, ok := obj.(*Array); ok {
+		//	arrays that contain themselves have no end to make a key from
+		if visiting[array] {
+			return false
+		}
+		visiting[array] = true
+		defer delete(visiting, array)
+
+		for _, el := range array.Elements {
+			if !hashable(el, visiting) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+//	freezeKey copies the arrays in a key so they cannot be changed once in a map
+func freezeKey(key Object) Object {
+	array, ok := key.(*Array)
+	if !ok {
+		return key
+	}
+
+	elements := make([]Object, len(array.Elements))
+	for i, el := range array.Elements {
+		elements[i] = freezeKey(el)
+	}
+
+	return &Array{ Elements: elements }
+}
+
 const (
 	INTEGER_OBJECT = "INTEGER"
+	BIGINT_OBJECT = "BIGINT"
+	DOUBLE_OBJECT = "DOUBLE"
 	BOOLEAN_OBJECT = "BOOLEAN"
 	NULL_OBJECT = "NULL"
 	RETURN_VALUE_OBJECT = "RETURN_VALUE"
@@ -28,6 +75,12 @@ const (
 	BUILTIN_OBJECT = "BUILTIN"
 	ARRAY_OBJECT = "ARRAY"
 	MAP_OBJECT = "MAP"
+	EXIT_OBJECT = "EXIT"
+	REGEX_OBJECT = "REGEX"
+	TIME_OBJECT = "TIME"
+	MODULE_OBJECT = "MODULE"
+	STRUCT_OBJECT = "STRUCT"
+	RECORD_OBJECT = "RECORD"
 )
 
 type Null struct {}
@@ -42,12 +95,13 @@ type ReturnValue struct {
 func (rv *ReturnValue) Type() ObjectType { return RETURN_VALUE_OBJECT }
 func (rv *ReturnValue) Inspect() string { return rv.Value.Inspect() }
 
-type Error struct {
-	Message string
+//	Exit stops the program with a status code
+type Exit struct {
+	Code int64
 }
 
-func (e *Error) Type() ObjectType { return ERROR_OBJECT }
-func (e *Error) Inspect() string { return "Error: " + e.Message }
+func (e *Exit) Type() ObjectType { return EXIT_OBJECT }
+func (e *Exit) Inspect() string { return fmt.Sprintf("exit(%d)", e.Code) }
 
 type Function struct {
 	Parameters []*ast.Identifier
@@ -1,6 +1,9 @@
 package object
 
-import "testing"
+import (
+	"math/big"
+	"testing"
+)
 
 func TestStringHashKey(t *testing.T) {
 	hello1 := &String{ Value: "Hello World" }
@@ -21,3 +24,247 @@ func TestStringHashKey(t *testing.T) {
 	}
 
 }
+
+func TestErrorTraceback(t *testing.T) {
+	err := &Error{
+		Message: "division by zero",
+		Line: 3,
+		Column: 12,
+		Stack: []Frame{
+			{ Function: "fact", Line: 4, Column: 10 },
+			{ Function: "fact", Line: 4, Column: 10 },
+			{ Function: "fact", Line: 4, Column: 10 },
+			{ Function: "main", Line: 8, Column: 5 },
+		},
+	}
+
+	expected := `Error: division by zero
+    at line 3, column 12
+    in fact, called at line 4, column 10
+    ... repeated 2 more times
+    in main, called at line 8, column 5`
+
+	if err.Traceback() != expected {
+		t.Errorf("Wrong traceback.\nExpected:\n%s\nGot:\n%s", expected, err.Traceback())
+	}
+}
+
+func TestBigIntMapKey(t *testing.T) {
+	small := &BigInt{ Value: big.NewInt(42) }
+	integer := &Integer{ Value: 42 }
+
+	if small.MapKey() != integer.MapKey() {
+		t.Errorf("big integers that fit in an integer have different map keys than the integer")
+	}
+
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	hugeAgain, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	negative := new(big.Int).Neg(huge)
+
+	if (&BigInt{ Value: huge }).MapKey() != (&BigInt{ Value: hugeAgain }).MapKey() {
+		t.Errorf("big integers with the same value have different map keys")
+	}
+
+	if (&BigInt{ Value: huge }).MapKey() == (&BigInt{ Value: negative }).MapKey() {
+		t.Errorf("big integers with different signs have the same map key")
+	}
+}
+
+func TestMapKeepsInsertionOrder(t *testing.T) {
+	m := NewMap()
+	m.Set(&String{ Value: "b" }, &Integer{ Value: 2 })
+	m.Set(&String{ Value: "a" }, &Integer{ Value: 1 })
+	m.Set(&Integer{ Value: 3 }, &Integer{ Value: 3 })
+	//	replacing a value keeps the key in place
+	m.Set(&String{ Value: "b" }, &Integer{ Value: 20 })
+
+	if m.Inspect() != "{b: 20, a: 1, 3: 3}" {
+		t.Fatalf("Wrong map order, got %s", m.Inspect())
+	}
+
+	if !m.Delete(&String{ Value: "a" }) {
+		t.Fatalf("Delete did not find the key a")
+	}
+
+	if m.Delete(&String{ Value: "a" }) {
+		t.Fatalf("Delete found a deleted key")
+	}
+
+	m.Set(&String{ Value: "a" }, &Integer{ Value: 1 })
+
+	if m.Inspect() != "{b: 20, 3: 3, a: 1}" {
+		t.Fatalf("Wrong map order after delete, got %s", m.Inspect())
+	}
+
+	if m.Len() != 3 {
+		t.Fatalf("Wrong map length, expected 3, got %d", m.Len())
+	}
+}
+
+//	collidingKey is a key whose MapKey is the same for every value.
+//	It is not a pointer, so two keys with the same value are equal
+type collidingKey string
+
+func (ck collidingKey) Type() ObjectType { return STRING_OBJECT }
+func (ck collidingKey) Inspect() string { return string(ck) }
+func (ck collidingKey) MapKey() MapKey { return MapKey{ Type: STRING_OBJECT, Value: 1 } }
+
+func TestMapKeyCollisions(t *testing.T) {
+	m := NewMap()
+	m.Set(collidingKey("first"), &Integer{ Value: 1 })
+	m.Set(collidingKey("second"), &Integer{ Value: 2 })
+
+	if m.Len() != 2 {
+		t.Fatalf("Colliding keys overwrote each other, map has %d pairs", m.Len())
+	}
+	//	the keys are looked up with new objects, so they are compared by value and not by pointer
+	for expected, name := range []string{ "first", "second" } {
+		value, ok := m.Get(collidingKey(name))
+		if !ok {
+			t.Fatalf("Colliding key %d not found", expected + 1)
+		}
+
+		if value.(*Integer).Value != int64(expected + 1) {
+			t.Errorf("Wrong value for colliding key. Expected %d, got %s", expected + 1, value.Inspect())
+		}
+	}
+
+	m.Delete(collidingKey("first"))
+
+	if _, ok := m.Get(collidingKey("first")); ok {
+		t.Fatalf("Deleted colliding key is still found")
+	}
+
+	if _, ok := m.Get(collidingKey("second")); !ok {
+		t.Fatalf("Deleting a colliding key removed the other one")
+	}
+}
+
+func TestArrayMapKeys(t *testing.T) {
+	point := &Array{ Elements: []Object{ &Integer{ Value: 1 }, &Integer{ Value: 2 } } }
+	samePoint := &Array{ Elements: []Object{ &Integer{ Value: 1 }, &Integer{ Value: 2 } } }
+	strings := &Array{ Elements: []Object{ &String{ Value: "1" }, &String{ Value: "2" } } }
+
+	if point.MapKey() != samePoint.MapKey() {
+		t.Errorf("arrays with the same elements have different map keys")
+	}
+
+	if point.MapKey() == strings.MapKey() {
+		t.Errorf("arrays with elements of different types have the same map key")
+	}
+
+	if Hashable(&Array{ Elements: []Object{ &Function{} } }) {
+		t.Errorf("arrays with functions should not be hashable")
+	}
+
+	m := NewMap()
+	m.Set(point, &Integer{ Value: 10 })
+	//	changing the array after using it as a key does not change the map
+	point.Elements = append(point.Elements, &Integer{ Value: 3 })
+
+	if _, ok := m.Get(samePoint); !ok {
+		t.Errorf("the key changed together with the array used to set it")
+	}
+}
+
+func TestDoubleInspect(t *testing.T) {
+	tests := []struct{
+		value float64
+		expected string
+	}{
+		{1.5, "1.5"},
+		{2, "2.0"},
+		{-0.25, "-0.25"},
+		{1e21, "1e+21"},
+	}
+
+	for _, tt := range tests {
+		double := &Double{ Value: tt.value }
+
+		if double.Inspect() != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, double.Inspect())
+		}
+	}
+}
+
+func TestInspectCycles(t *testing.T) {
+	m := NewMap()
+	m.Set(&String{ Value: "a" }, &Integer{ Value: 1 })
+	m.Set(&String{ Value: "self" }, m)
+
+	array := &Array{ Elements: []Object{ &Integer{ Value: 1 } } }
+	array.Elements = append(array.Elements, array, m)
+
+	node := &Struct{ Name: "Node", Fields: []StructField{ { Name: "next", Type: "array" } } }
+	record := &Record{ Struct: node, Fields: map[string]Object{} }
+	record.Fields["next"] = &Array{ Elements: []Object{ record } }
+
+	tests := []struct{
+		value Object
+		expected string
+	}{
+		{m, "{a: 1, self: {...}}"},
+		{array, "[1, [...], {a: 1, self: {...}}]"},
+		{record, "Node{next: [Node{...}]}"},
+		//	a container that appears twice without holding itself is printed both times
+		{&Array{ Elements: []Object{ m, m } }, "[{a: 1, self: {...}}, {a: 1, self: {...}}]"},
+	}
+
+	for _, tt := range tests {
+		if tt.value.Inspect() != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, tt.value.Inspect())
+		}
+	}
+
+	loop := &Array{ Elements: []Object{ &Integer{ Value: 1 } } }
+	loop.Elements = append(loop.Elements, loop)
+
+	if Hashable(loop) {
+		t.Errorf("an array that contains itself is hashable")
+	}
+}
+
+func TestDoubleMapKeys(t *testing.T) {
+	whole := &Double{ Value: 2 }
+	integer := &Integer{ Value: 2 }
+
+	if whole.MapKey() != integer.MapKey() {
+		t.Errorf("whole doubles should have the same key as the equal integer")
+	}
+
+	if (&Double{ Value: 2.5 }).MapKey() == (&Double{ Value: 3.5 }).MapKey() {
+		t.Errorf("different doubles have the same key")
+	}
+
+	m := NewMap()
+	m.Set(integer, &String{ Value: "two" })
+
+	if value, ok := m.Get(whole); !ok || value.Inspect() != "two" {
+		t.Errorf("expected 2.0 to find the value stored with 2, got %v", value)
+	}
+}
+
+func TestRecordEquality(t *testing.T) {
+	point := &Struct{ Name: "Point", Fields: []StructField{ { Name: "x", Type: "int" }, { Name: "y", Type: "int" } } }
+	other := &Struct{ Name: "Other", Fields: point.Fields }
+
+	record := func(structure *Struct, x, y int64) *Record {
+		return &Record{ Struct: structure, Fields: map[string]Object{ "x": &Integer{ Value: x }, "y": &Integer{ Value: y } } }
+	}
+
+	if !Equal(record(point, 1, 2), record(point, 1, 2)) {
+		t.Errorf("records with the same fields should be equal")
+	}
+
+	if Equal(record(point, 1, 2), record(point, 1, 3)) {
+		t.Errorf("records with different fields should not be equal")
+	}
+
+	if Equal(record(point, 1, 2), record(other, 1, 2)) {
+		t.Errorf("records of different structs should not be equal")
+	}
+
+	if record(point, 1, 2).Inspect() != "Point{x: 1, y: 2}" {
+		t.Errorf("expected Point{x: 1, y: 2}, got %q", record(point, 1, 2).Inspect())
+	}
+}
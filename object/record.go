@@ -0,0 +1,59 @@
+package object
+
+import "bytes"
+
+//	StructField is a field of a struct, with the name of its type
+type StructField struct {
+	Name string
+	Type string //	a data type like int, or the name of another struct
+}
+
+//	Struct is a type declared with struct, like struct Point { int x; int y; }
+type Struct struct {
+	Name string
+	Fields []StructField //	fields in the order they were declared
+}
+
+func (s *Struct) Type() ObjectType { return STRUCT_OBJECT }
+func (s *Struct) Inspect() string {
+	var out bytes.Buffer
+
+	out.WriteString("struct " + s.Name + " {")
+	for _, field := range s.Fields {
+		out.WriteString(" " + field.Type + " " + field.Name + ";")
+	}
+	out.WriteString(" }")
+
+	return out.String()
+}
+
+//	Field returns the field with the given name
+func (s *Struct) Field(name string) (StructField, bool) {
+	for _, field := range s.Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+
+	return StructField{}, false
+}
+
+//	Record is a value of a struct. Records of every struct share the same type,
+//	so the struct they are of is compared separately
+type Record struct {
+	Struct *Struct
+	Fields map[string]Object
+}
+
+func (r *Record) Type() ObjectType { return RECORD_OBJECT }
+func (r *Record) Inspect() string { return inspect(r, make(map[Object]bool)) }
+
+//	TypeName is the name of the type of the value shown to users,
+//	which for records is the name of their struct
+func TypeName(obj Object) string {
+	if record, ok := obj.(*Record); ok {
+		return record.Struct.Name
+	}
+
+	return string(obj.Type())
+}
@@ -0,0 +1,12 @@
+package object
+
+import "regexp"
+
+//	Regex is a compiled regular expression
+type Regex struct {
+	Pattern string
+	Value *regexp.Regexp
+}
+
+func (r *Regex) Type() ObjectType { return REGEX_OBJECT }
+func (r *Regex) Inspect() string { return "/" + r.Pattern + "/" }
@@ -0,0 +1,11 @@
+package object
+
+import "time"
+
+//	Time is a point in time
+type Time struct {
+	Value time.Time
+}
+
+func (t *Time) Type() ObjectType { return TIME_OBJECT }
+func (t *Time) Inspect() string { return t.Value.Format(time.RFC3339Nano) }
@@ -5,6 +5,7 @@ import (
 	"language/ast"
 	"language/lexer"
 	"language/token"
+	"math/big"
 	"strconv"
 )
 
@@ -20,6 +21,7 @@ const (
 	PREFIX //	-X or !X
 	CALL //	myFunc(X)
 	INDEX //	array[index]
+	POSTFIX //	myFunc(X)?
 )
 
 var dataTypes = []token.TokenType{
@@ -31,6 +33,7 @@ var dataTypes = []token.TokenType{
 	token.MAP,
 	token.ANY,
 	token.FUNCTION_TYPE,
+	token.TIME,
 }
 
 var precedences = map[token.TokenType]int{
@@ -48,7 +51,10 @@ var precedences = map[token.TokenType]int{
 	token.MULTIPLY: PRODUCT,
 	token.L_PAREN: CALL,
 	token.L_BRACK: INDEX,
+	token.DOT: INDEX,
+	token.L_BRACE: INDEX,
 	token.IN: INDEX,
+	token.QUESTION: POSTFIX,
 }
 
 type (
@@ -59,6 +65,8 @@ type (
 type Parser struct {
 	l *lexer.Lexer
 	errors []string
+	reported map[string]bool //	errors already reported, to avoid repeating them
+	structs map[string]bool //	names of the structs declared so far, which can be used as types
 
 	currentToken token.Token //	current token being read
 	peekToken token.Token //	next token being peeked
@@ -68,7 +76,7 @@ type Parser struct {
 }
 
 func New(l *lexer.Lexer) *Parser {
-	p := &Parser{ l: l, errors: []string{} }
+	p := &Parser{ l: l, errors: []string{}, reported: make(map[string]bool), structs: make(map[string]bool) }
 
 	//	read two tokens to set both currentToken and peerToken
 	p.nextToken()
@@ -78,6 +86,7 @@ func New(l *lexer.Lexer) *Parser {
 	p.prefixParseFuncs = make(map[token.TokenType]prefixParseFunc)
 	p.registerPrefix(token.IDENTIFIER, p.parseIdentifier)
 	p.registerPrefix(token.INT, p.parseIntegerLiteral)
+	p.registerPrefix(token.DOUBLE, p.parseDoubleLiteral)
 	p.registerPrefix(token.NOT, p.parsePrefixExpression)
 	p.registerPrefix(token.MINUS, p.parsePrefixExpression)
 	p.registerPrefix(token.TRUE, p.parseBoolean)
@@ -105,6 +114,9 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerInfix(token.OR, p.parseInfixExpression)
 	p.registerInfix(token.L_PAREN, p.parseCallExpression)
 	p.registerInfix(token.L_BRACK, p.parseIndexExpression)
+	p.registerInfix(token.DOT, p.parseMemberExpression)
+	p.registerInfix(token.L_BRACE, p.parseStructLiteral)
+	p.registerInfix(token.QUESTION, p.parsePropagateExpression)
 	// p.registerInfix(token.IN, p.parseInfixExpression)
 
 	//	returns the parser
@@ -138,6 +150,230 @@ func (p *Parser) parseForStatement() ast.Statement {
 	return statement
 }
 
+func (p *Parser) parseTryStatement() ast.Statement {
+	statement := &ast.TryStatement{ Token: p.currentToken }
+	//	the try keyword must be followed by a block
+	if !p.expectPeek(token.L_BRACE) {
+		return nil
+	}
+	statement.Block = p.parseBlockStatement()
+	//	the catch block is optional and names the caught error between parentheses
+	if p.peekTokenIs(token.CATCH) {
+		p.nextToken()
+
+		if !p.expectPeek(token.L_PAREN) {
+			return nil
+		}
+
+		if !p.expectPeek(token.IDENTIFIER) {
+			return nil
+		}
+		statement.Parameter = &ast.Identifier{ Token: p.currentToken, Value: p.currentToken.Literal }
+
+		if !p.expectPeek(token.R_PAREN) {
+			return nil
+		}
+
+		if !p.expectPeek(token.L_BRACE) {
+			return nil
+		}
+		statement.Catch = p.parseBlockStatement()
+	}
+	//	the finally block is optional too
+	if p.peekTokenIs(token.FINALLY) {
+		p.nextToken()
+
+		if !p.expectPeek(token.L_BRACE) {
+			return nil
+		}
+		statement.Finally = p.parseBlockStatement()
+	}
+	//	but at least one of them has to be there
+	if statement.Catch == nil && statement.Finally == nil {
+		p.addError(
+			p.peekToken,
+			"Expected catch or finally after the try block but received %s (%q)",
+			p.peekToken.Type,
+			p.peekToken.Literal,
+		)
+		return nil
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return statement
+}
+
+func (p *Parser) parseThrowStatement() ast.Statement {
+	statement := &ast.ThrowStatement{ Token: p.currentToken }
+	//	goes to the value being thrown
+	p.nextToken()
+	statement.Value = p.parseExpression(LOWEST)
+	//	if the value could not be parsed its error was already reported
+	if statement.Value == nil {
+		return nil
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return statement
+}
+
+func (p *Parser) parseImportStatement() ast.Statement {
+	statement := &ast.ImportStatement{ Token: p.currentToken }
+	//	the path of the file is a string, and the module is always given a name
+	if !p.expectPeek(token.STRING) {
+		return nil
+	}
+	statement.Path = &ast.StringLiteral{ Token: p.currentToken, Value: p.currentToken.Literal }
+
+	if !p.expectPeek(token.AS) {
+		return nil
+	}
+
+	if !p.expectPeek(token.IDENTIFIER) {
+		return nil
+	}
+	statement.Name = &ast.Identifier{ Token: p.currentToken, Value: p.currentToken.Literal }
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return statement
+}
+
+func (p *Parser) parseExportStatement() ast.Statement {
+	statement := &ast.ExportStatement{ Token: p.currentToken }
+	//	only variables, constants and structs can be exported
+	p.nextToken()
+	switch p.currentToken.Type {
+	case token.VAR:
+		if declaration, ok := p.parseVarStatement().(*ast.VarStatement); ok {
+			statement.Statement, statement.Name = declaration, declaration.Name
+		}
+	case token.CONST:
+		if declaration, ok := p.parseConstStatement().(*ast.ConstStatement); ok {
+			statement.Statement, statement.Name = declaration, declaration.Name
+		}
+	case token.STRUCT:
+		if declaration, ok := p.parseStructStatement().(*ast.StructStatement); ok {
+			statement.Statement, statement.Name = declaration, declaration.Name
+		}
+	default:
+		p.addError(
+			p.currentToken,
+			"Expected var, const or struct after export but received %s (%q)",
+			p.currentToken.Type,
+			p.currentToken.Literal,
+		)
+	}
+	//	if the declaration could not be parsed its error was already reported
+	if statement.Statement == nil {
+		return nil
+	}
+
+	return statement
+}
+
+func (p *Parser) parseStructStatement() ast.Statement {
+	statement := &ast.StructStatement{ Token: p.currentToken }
+
+	if !p.expectPeek(token.IDENTIFIER) {
+		return nil
+	}
+	statement.Name = &ast.Identifier{ Token: p.currentToken, Value: p.currentToken.Literal }
+	p.structs[statement.Name.Value] = true
+
+	if !p.expectPeek(token.L_BRACE) {
+		return nil
+	}
+	//	each field is a type and a name, ended by a semicolon
+	declared := make(map[string]bool)
+	for !p.peekTokenIs(token.R_BRACE) {
+		if !p.expectDataType() {
+			return nil
+		}
+		field := &ast.StructField{ Type: p.currentToken }
+
+		if !p.expectPeek(token.IDENTIFIER) {
+			return nil
+		}
+		field.Name = &ast.Identifier{ Token: p.currentToken, Value: p.currentToken.Literal }
+
+		if declared[field.Name.Value] {
+			p.addError(p.currentToken, "Field %s is declared more than once in %s", field.Name.Value, statement.Name.Value)
+			return nil
+		}
+		declared[field.Name.Value] = true
+		statement.Fields = append(statement.Fields, field)
+		//	the semicolon of the last field can be left out
+		if !p.peekTokenIs(token.R_BRACE) && !p.expectPeek(token.SEMICOLON) {
+			return nil
+		}
+	}
+
+	if !p.expectPeek(token.R_BRACE) {
+		return nil
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return statement
+}
+
+func (p *Parser) parseStructLiteral(left ast.Expression) ast.Expression {
+	literal := &ast.StructLiteral{ Token: p.currentToken, Struct: left }
+	//	only a name can be followed by a brace, like Point{ or geo.Point{
+	switch left.(type) {
+	case *ast.Identifier, *ast.MemberExpression:
+	default:
+		p.addError(p.currentToken, "Expected a struct name before %q but received %s", p.currentToken.Literal, left.String())
+		return nil
+	}
+
+	given := make(map[string]bool)
+	for !p.peekTokenIs(token.R_BRACE) {
+		if !p.expectPeek(token.IDENTIFIER) {
+			return nil
+		}
+		field := &ast.FieldValue{ Name: &ast.Identifier{ Token: p.currentToken, Value: p.currentToken.Literal } }
+
+		if given[field.Name.Value] {
+			p.addError(p.currentToken, "Field %s is given more than once", field.Name.Value)
+			return nil
+		}
+		given[field.Name.Value] = true
+
+		if !p.expectPeek(token.COLON) {
+			return nil
+		}
+
+		p.nextToken()
+		field.Value = p.parseExpression(LOWEST)
+		if field.Value == nil {
+			return nil
+		}
+		literal.Fields = append(literal.Fields, field)
+
+		if !p.peekTokenIs(token.R_BRACE) && !p.expectPeek(token.COMMA) {
+			return nil
+		}
+	}
+
+	if !p.expectPeek(token.R_BRACE) {
+		return nil
+	}
+
+	return literal
+}
+
 func (p *Parser) parseMapLiteral() ast.Expression {
 	//	creates the map
 	hash := &ast.MapLiteral{ Token: p.currentToken }
@@ -160,6 +396,7 @@ func (p *Parser) parseMapLiteral() ast.Expression {
 		value := p.parseExpression(LOWEST)
 		//	asigns the value to the key
 		hash.Pairs[key] = value
+		hash.Keys = append(hash.Keys, key)
 		//	if the next token is not a } or a ,
 		//	return nil as it is an invalid map
 		if !p.peekTokenIs(token.R_BRACE) && !p.expectPeek(token.COMMA) {
@@ -176,11 +413,35 @@ func (p *Parser) parseMapLiteral() ast.Expression {
 }
 
 func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
-	expression := &ast.IndexExpression{ Token: p.currentToken, Left: left }
+	bracket := p.currentToken
 
 	p.nextToken()
+	//	a slice with no start, like arr[:2]
+	if p.currentTokenIs(token.COLON) {
+		return p.parseSliceExpression(bracket, left, nil)
+	}
+
+	index := p.parseExpression(LOWEST)
 
-	expression.Index = p.parseExpression(LOWEST)
+	if p.peekTokenIs(token.COLON) {
+		p.nextToken()
+		return p.parseSliceExpression(bracket, left, index)
+	}
+
+	if !p.expectPeek(token.R_BRACK) {
+		return nil
+	}
+
+	return &ast.IndexExpression{ Token: bracket, Left: left, Index: index }
+}
+
+func (p *Parser) parseSliceExpression(bracket token.Token, left, start ast.Expression) ast.Expression {
+	expression := &ast.SliceExpression{ Token: bracket, Left: left, Start: start }
+	//	the current token is the colon, and the end can be left out, like arr[2:]
+	if !p.peekTokenIs(token.R_BRACK) {
+		p.nextToken()
+		expression.End = p.parseExpression(LOWEST)
+	}
 
 	if !p.expectPeek(token.R_BRACK) {
 		return nil
@@ -189,6 +450,24 @@ func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
 	return expression
 }
 
+func (p *Parser) parseMemberExpression(left ast.Expression) ast.Expression {
+	expression := &ast.MemberExpression{ Token: p.currentToken, Left: left }
+	//	the name after the dot is read as it is written, even if it is a keyword like map
+	if !p.peekTokenIs(token.IDENTIFIER) && !token.IsDataType(p.peekToken.Literal) {
+		p.peekError(token.IDENTIFIER)
+		return nil
+	}
+	p.nextToken()
+	expression.Property = &ast.Identifier{ Token: p.currentToken, Value: p.currentToken.Literal }
+
+	return expression
+}
+
+func (p *Parser) parsePropagateExpression(left ast.Expression) ast.Expression {
+	//	the ? operator goes after the expression and takes no right side
+	return &ast.PropagateExpression{ Token: p.currentToken, Left: left }
+}
+
 func (p *Parser) parseExpressionList(end token.TokenType) []ast.Expression {
 	list := []ast.Expression{}
 	//	if the immediate next token is a right bracket (]) it is an empty list
@@ -317,9 +596,17 @@ func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 	p.nextToken()
 	//	loops until an end of file or a right brace is found, meaning the end of the block or the end of the file
 	for !p.currentTokenIs(token.R_BRACE) && !p.currentTokenIs(token.EOF) {
+		errorCount := len(p.errors)
 		statement := p.parseStatement()
+		//	a module exports the variables it declares at the top, not the ones inside blocks
+		if export, ok := statement.(*ast.ExportStatement); ok {
+			p.addError(export.Token, "Exports are only allowed at the top level of a file")
+		}
 
-		if statement != nil {
+		if statement == nil {
+			//	skips the rest of the broken statement
+			p.synchronize()
+		} else if len(p.errors) == errorCount {
 			block.Statements = append(block.Statements, statement)
 		}
 
@@ -417,6 +704,13 @@ func (p *Parser) parseIdentifier() ast.Expression {
 	return &ast.Identifier{ Token: p.currentToken, Value: p.currentToken.Literal }
 }
 
+func (p *Parser) parseDataTypeIdentifier() ast.Expression {
+	//	the keyword is read as the identifier of the builtin with its name
+	name := p.currentToken
+	name.Type = token.IDENTIFIER
+	return &ast.Identifier{ Token: name, Value: name.Literal }
+}
+
 func (p *Parser) Errors() []string {
 	return p.errors
 }
@@ -436,13 +730,31 @@ func (p *Parser) peekTokenIs(t token.TokenType) bool {
 	return p.peekToken.Type == t
 }
 
-func (p *Parser) peekError(t token.TokenType) {
-	//	prints and stores an error message
-	message := fmt.Sprintf("Expected token to be %s but received %s", t, p.peekToken.Type)
+func (p *Parser) addError(tok token.Token, format string, a ...interface{}) {
+	//	prefixes the message with the position of the token that caused it
+	message := fmt.Sprintf(
+		"[line %d, column %d] %s", tok.Line, tok.Column, fmt.Sprintf(format, a...),
+	)
+	//	the same error on the same token is only reported once
+	if p.reported[message] {
+		return
+	}
+	p.reported[message] = true
 	//	adds it to the error array of the parser
 	p.errors = append(p.errors, message)
 }
 
+func (p *Parser) peekError(t token.TokenType) {
+	//	stores an error message with both the expected and the received tokens
+	p.addError(
+		p.peekToken,
+		"Expected token to be %s but received %s (%q)",
+		t,
+		p.peekToken.Type,
+		p.peekToken.Literal,
+	)
+}
+
 func (p *Parser) peekPrecedence() int {
 	//	checks that the next token has a priority on the precedences
 	if p, ok := precedences[p.peekToken.Type]; ok {
@@ -471,17 +783,24 @@ func (p *Parser) expectPeek(t token.TokenType) bool {
 	}
 }
 
-func (p *Parser) typeError(expectedType, actualType token.TokenType, statement string) {
-	message := fmt.Sprintf(
-		"Expected type %s, got %s on: %s", expectedType, actualType, statement,
-	)
-	p.errors = append(p.errors, message)
+func (p *Parser) typeError(
+	tok token.Token,
+	expectedType, actualType token.TokenType,
+	statement string,
+) {
+	//	struct types are shown by their name
+	if expectedType == token.IDENTIFIER {
+		expectedType = token.TokenType(tok.Literal)
+	}
+	p.addError(tok, "Expected type %s, got %s on: %s", expectedType, actualType, statement)
 }
 
 func (p *Parser) inferType(value ast.Expression) token.TokenType {
 	switch value.(type) {
 	case *ast.IntegerLiteral:
 		return token.INT
+	case *ast.DoubleLiteral:
+		return token.DOUBLE
 	case *ast.StringLiteral:
 		return token.STRING
 	case *ast.Boolean:
@@ -500,6 +819,16 @@ func (p *Parser) inferType(value ast.Expression) token.TokenType {
 		return token.ANY
 	case *ast.CallExpression:
 		return token.ANY
+	case *ast.PropagateExpression:
+		return token.ANY
+	case *ast.IndexExpression:
+		return token.ANY
+	case *ast.SliceExpression:
+		return token.ANY
+	case *ast.MemberExpression:
+		return token.ANY
+	case *ast.StructLiteral:
+		return token.STRUCT
 	default:
 		return token.ILLEGAL
 	}
@@ -507,23 +836,53 @@ func (p *Parser) inferType(value ast.Expression) token.TokenType {
 
 func (p *Parser) typeCheck(expectedType token.Token, value ast.Expression) bool {
 	valueType := p.inferType(value)
+	//	struct types are named by identifiers, the struct of the record is checked when it runs
+	if expectedType.Type == token.IDENTIFIER {
+		return valueType == token.STRUCT
+	}
 	return valueType == expectedType.Type
 }
 
-func (p *Parser) parseConstStatement() *ast.ConstStatement {
-	//	creates the statement object and assigns its memory address to a variable
-	statement := &ast.ConstStatement{ Token: p.currentToken}
-	//	used to store the data type
-	var foundType token.TokenType
+func (p *Parser) expectDataType() bool {
 	//	checks if the next token is a data type token
 	for _, dataType := range dataTypes  {
-		if p.peekToken.Type == dataType {
-			foundType = dataType
-			break //	once a valid data type is found, break the loop
+		if p.peekTokenIs(dataType) {
+			p.nextToken()
+			return true
 		}
 	}
+	//	the name of a struct is a type when it is followed by the name being declared, like Point p
+	if p.peekTokenIs(token.IDENTIFIER) {
+		typeName := p.peekToken
+		p.nextToken()
+
+		if !p.peekTokenIs(token.IDENTIFIER) {
+			p.addError(typeName, "Expected a data type but received %s (%q)", typeName.Type, typeName.Literal)
+			return false
+		}
+		//	structs have to be declared before their name is used as a type
+		if !p.structs[typeName.Literal] {
+			p.addError(typeName, "Unknown type %s", typeName.Literal)
+			return false
+		}
+
+		return true
+	}
+
+	p.addError(
+		p.peekToken,
+		"Expected a data type but received %s (%q)",
+		p.peekToken.Type,
+		p.peekToken.Literal,
+	)
+	return false
+}
+
+func (p *Parser) parseConstStatement() ast.Statement {
+	//	creates the statement object and assigns its memory address to a variable
+	statement := &ast.ConstStatement{ Token: p.currentToken}
 	//	if the type is not found, it is not a valid statement
-	if !p.expectPeek(foundType) {
+	if !p.expectDataType() {
 		return nil
 	}
 	//	sets the const type as the found type
@@ -544,34 +903,31 @@ func (p *Parser) parseConstStatement() *ast.ConstStatement {
 	p.nextToken()
 	//	sets the value for the variable
 	statement.Value = p.parseExpression(LOWEST)
+	//	if the value could not be parsed its error was already reported
+	if statement.Value == nil {
+		return nil
+	}
 	//	checks that the type is matched
 	if (!p.typeCheck(statement.Type, statement.Value) &&
 		p.inferType(statement.Value) != token.ANY) {
-		p.typeError(statement.Type.Type, p.inferType(statement.Value), statement.String())
+		p.typeError(
+			statement.Type, statement.Type.Type, p.inferType(statement.Value), statement.String(),
+		)
 		return nil
 	}
 
-	for !p.currentTokenIs(token.SEMICOLON) {
+	for !p.currentTokenIs(token.SEMICOLON) && !p.currentTokenIs(token.EOF) {
 		p.nextToken()
 	}
 
 	return statement
 }
 
-func (p *Parser) parseVarStatement() *ast.VarStatement {
+func (p *Parser) parseVarStatement() ast.Statement {
 	//	creates the statement object and assigns its memory address to a variable
 	statement := &ast.VarStatement{ Token: p.currentToken}
-	//	used to store the data type
-	var foundType token.TokenType
-	//	checks if the next token is a data type token
-	for _, dataType := range dataTypes  {
-		if p.peekToken.Type == dataType {
-			foundType = dataType
-			break //	once a valid data type is found, break the loop
-		}
-	}
 	//	if the type is not found, it is not a valid statement
-	if !p.expectPeek(foundType) {
+	if !p.expectDataType() {
 		return nil
 	}
 	//	sets the type of the variable
@@ -591,15 +947,21 @@ func (p *Parser) parseVarStatement() *ast.VarStatement {
 	p.nextToken()
 	//	sets the value for the variable
 	statement.Value = p.parseExpression(LOWEST)
+	//	if the value could not be parsed its error was already reported
+	if statement.Value == nil {
+		return nil
+	}
 
 	//	checks that the type is matched
 	if (!p.typeCheck(statement.Type, statement.Value) &&
 		p.inferType(statement.Value) != token.ANY) {
-		p.typeError(statement.Type.Type, p.inferType(statement.Value), statement.String())
+		p.typeError(
+			statement.Type, statement.Type.Type, p.inferType(statement.Value), statement.String(),
+		)
 		return nil
 	}
 
-	for !p.currentTokenIs(token.SEMICOLON) {
+	for !p.currentTokenIs(token.SEMICOLON) && !p.currentTokenIs(token.EOF) {
 		p.nextToken()
 	}
 
@@ -615,24 +977,27 @@ func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
 	//	sets the return value
 	statement.ReturnValue = p.parseExpression(LOWEST)
 
-	for !p.currentTokenIs(token.SEMICOLON) {
+	for !p.currentTokenIs(token.SEMICOLON) && !p.currentTokenIs(token.EOF) {
 		p.nextToken()
 	}
 
 	return statement
 }
 
-func (p *Parser) noPrefixParseError(t token.TokenType) {
-	message := fmt.Sprintf("No prefix function for %s found", t)
-	p.errors = append(p.errors, message)
+func (p *Parser) noPrefixParseError(tok token.Token) {
+	p.addError(tok, "No prefix function for %s (%q) found", tok.Type, tok.Literal)
 }
 
 func (p *Parser) parseExpression(precedence int) ast.Expression {
 	//	gets the function corresponding to the token type being parsed
 	prefix := p.prefixParseFuncs[p.currentToken.Type]
+	//	data type keywords used as values are the builtins with their name, like map(arr, fn)
+	if p.currentToken.Keyword && token.IsDataType(p.currentToken.Literal) {
+		prefix = p.parseDataTypeIdentifier
+	}
 
 	if prefix == nil {
-		p.noPrefixParseError(p.currentToken.Type)
+		p.noPrefixParseError(p.currentToken)
 		return nil
 	}
 	//	calls the prefixFunc found
@@ -676,9 +1041,13 @@ func (p *Parser) parseIntegerLiteral() ast.Expression {
 	value, err := strconv.ParseInt(p.currentToken.Literal, 0, 64)
 
 	if err != nil {
+		//	integers too big for an int64 are kept as big integers
+		if bigValue, ok := new(big.Int).SetString(p.currentToken.Literal, 0); ok {
+			literal.Big = bigValue
+			return literal
+		}
 		//	creates an error message and appends it to the parser error list
-		message := fmt.Sprintf("Could not parse %q as integer", p.currentToken.Literal)
-		p.errors = append(p.errors, message)
+		p.addError(p.currentToken, "Could not parse %q as integer", p.currentToken.Literal)
 		return nil
 	}
 
@@ -688,7 +1057,22 @@ func (p *Parser) parseIntegerLiteral() ast.Expression {
 	return literal
 }
 
-func (p *Parser) parseReassignStatement() *ast.ReassignStatement {
+func (p *Parser) parseDoubleLiteral() ast.Expression {
+	literal := &ast.DoubleLiteral{ Token: p.currentToken }
+
+	value, err := strconv.ParseFloat(p.currentToken.Literal, 64)
+
+	if err != nil {
+		p.addError(p.currentToken, "Could not parse %q as double", p.currentToken.Literal)
+		return nil
+	}
+
+	literal.Value = value
+
+	return literal
+}
+
+func (p *Parser) parseReassignStatement() ast.Statement {
 	statement := &ast.ReassignStatement{ Token: p.currentToken }
 
 	statement.Name = &ast.Identifier{ Token: p.currentToken, Value: p.currentToken.Literal }
@@ -719,6 +1103,16 @@ func (p *Parser) parseStatement() ast.Statement {
 		return p.parseReturnStatement()
 	case token.FOR:
 		return p.parseForStatement()
+	case token.TRY:
+		return p.parseTryStatement()
+	case token.THROW:
+		return p.parseThrowStatement()
+	case token.IMPORT:
+		return p.parseImportStatement()
+	case token.EXPORT:
+		return p.parseExportStatement()
+	case token.STRUCT:
+		return p.parseStructStatement()
 	case token.IDENTIFIER:
 		if p.peekTokenIs(token.ASSIGN) {
 			return p.parseReassignStatement()
@@ -729,6 +1123,37 @@ func (p *Parser) parseStatement() ast.Statement {
 	}
 }
 
+//	statementStarts are the tokens that can only appear at the beginning of a statement
+var statementStarts = []token.TokenType{
+	token.VAR,
+	token.CONST,
+	token.RETURN,
+	token.FOR,
+	token.TRY,
+	token.THROW,
+	token.IMPORT,
+	token.EXPORT,
+	token.STRUCT,
+}
+
+func (p *Parser) synchronize() {
+	//	skips tokens until the end of the current statement (a semicolon),
+	//	or until the next token starts a new statement or closes the current block
+	for !p.currentTokenIs(token.SEMICOLON) && !p.currentTokenIs(token.EOF) {
+		if p.peekTokenIs(token.R_BRACE) || p.peekTokenIs(token.EOF) {
+			return
+		}
+
+		for _, start := range statementStarts {
+			if p.peekTokenIs(start) {
+				return
+			}
+		}
+
+		p.nextToken()
+	}
+}
+
 func (p *Parser) registerPrefix(tokenType token.TokenType, fn prefixParseFunc) {
 	//	registers the prefix parser function a the token type
 	p.prefixParseFuncs[tokenType] = fn
@@ -747,11 +1172,17 @@ func (p *Parser) ParserProgram() *ast.Program {
 
 	//	loop through the tokens while the current token is not an EOF type
 	for !p.currentTokenIs(token.EOF) {
+		//	keeps the amount of errors to know if the statement added new ones
+		errorCount := len(p.errors)
 		//	gets the parsed statement
 		statement := p.parseStatement()
 
-		//	if the statement is not nil, append it to the statement array
-		if statement != nil {
+		if statement == nil {
+			//	if the statement could not be parsed, skip to the next one
+			//	instead of reporting errors for the leftover tokens
+			p.synchronize()
+		} else if len(p.errors) == errorCount {
+			//	if the statement has no errors, append it to the statement array
 			program.Statements = append(program.Statements, statement)
 		}
 		//	goes to the next token of the input
@@ -283,6 +283,70 @@ func TestIntegerLiteral(t *testing.T) {
 	}
 }
 
+func TestBigIntegerLiteral(t *testing.T) {
+	input := `9223372036854775808;`
+
+	l := lexer.New(input)
+	p := New(l)
+
+	program := p.ParserProgram()
+	checkParserErrors(t, p)
+
+	statement, ok := program.Statements[0].(*ast.ExpressionStatement)
+
+	if !ok {
+		t.Fatalf("Statement is not ast.ExpressionStatement. Got %T", program.Statements[0])
+	}
+
+	literal, ok := statement.Expression.(*ast.IntegerLiteral)
+
+	if !ok {
+		t.Fatalf("Statement is not ast.IntegerLiteral, got %T", statement.Expression)
+	}
+
+	if literal.Big == nil || literal.Big.String() != "9223372036854775808" {
+		t.Fatalf("literal.Big not %s, got %v", "9223372036854775808", literal.Big)
+	}
+
+	if literal.String() != "9223372036854775808" {
+		t.Fatalf("literal.String not %s, got %s", "9223372036854775808", literal.String())
+	}
+}
+
+func TestDoubleLiteral(t *testing.T) {
+	input := `var double d = 2.5;`
+
+	l := lexer.New(input)
+	p := New(l)
+
+	program := p.ParserProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("Program has not enough statements. got %d", len(program.Statements))
+	}
+
+	statement, ok := program.Statements[0].(*ast.VarStatement)
+
+	if !ok {
+		t.Fatalf("Statement is not ast.VarStatement. Got %T", program.Statements[0])
+	}
+
+	literal, ok := statement.Value.(*ast.DoubleLiteral)
+
+	if !ok {
+		t.Fatalf("Value is not ast.DoubleLiteral, got %T", statement.Value)
+	}
+
+	if literal.Value != 2.5 {
+		t.Fatalf("literal.Value not %f, got %f", 2.5, literal.Value)
+	}
+
+	if literal.TokenLiteral() != "2.5" {
+		t.Fatalf("literal.TokenLiteral not %s, got %s", "2.5", literal.TokenLiteral())
+	}
+}
+
 func TestParsingPrefixExpressions(t *testing.T) {
 	prefixTests := []struct {
 		input string
@@ -520,6 +584,18 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 			"add(a + b + c * d / f + g)",
 			"add((((a + b) + ((c * d) / f)) + g))",
 		},
+		{
+			"add(a)? + b",
+			"((add(a)?) + b)",
+		},
+		{
+			"-a?",
+			"(-(a?))",
+		},
+		{
+			"a[0]?",
+			"((a[0])?)",
+		},
 		{
 			"a * [1, 2, 3, 4][b * c] * d",
 			"((a * ([1, 2, 3, 4][(b * c)])) * d)",
@@ -960,6 +1036,70 @@ func TestParsingIndexExpressions(t *testing.T) {
 	}
 }
 
+func TestParsingSliceExpressions(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{"myArray[1:3]", "(myArray[1:3])"},
+		{"myArray[:2]", "(myArray[:2])"},
+		{"myArray[2:]", "(myArray[2:])"},
+		{"myArray[:]", "(myArray[:])"},
+		{"myArray[-2:-1]", "(myArray[(-2):(-1)])"},
+		{"myArray[1 + 1:length(myArray)][0]", "((myArray[(1 + 1):length(myArray)])[0])"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+
+		program := p.ParserProgram()
+		checkParserErrors(t, p)
+
+		statement, ok := program.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not *ast.ExpressionStatement, got %T", program.Statements[0])
+		}
+
+		if statement.Expression.String() != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, statement.Expression.String())
+		}
+	}
+}
+
+func TestIndexExpressionTypes(t *testing.T) {
+	input := `var int first = myArray[0]; var array rest = myArray[1:];`
+
+	l := lexer.New(input)
+	p := New(l)
+
+	program := p.ParserProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 2 {
+		t.Fatalf("program.Statements does not contain 2 statements, got %d", len(program.Statements))
+	}
+}
+
+func TestTimeVarStatement(t *testing.T) {
+	input := `var time start = now();`
+
+	l := lexer.New(input)
+	p := New(l)
+
+	program := p.ParserProgram()
+	checkParserErrors(t, p)
+
+	statement, ok := program.Statements[0].(*ast.VarStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not *ast.VarStatement, got %T", program.Statements[0])
+	}
+
+	if statement.Type.Type != token.TIME {
+		t.Errorf("expected type %s, got %s", token.TIME, statement.Type.Type)
+	}
+}
+
 func TestHashLiteralStringKeys(t *testing.T) {
 	input := `{"one": 1, "two": 2, "three": 3}`
 
@@ -1587,3 +1727,348 @@ func testInfixExpression(
 
 	return true
 }
+
+func TestParserErrorRecovery(t *testing.T) {
+	input := `
+var x = 5;
+var int y = 10;
+const int z = ;
+var int w = (1 + 2;
+y = 20;
+`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParserProgram()
+
+	expectedErrors := []string{
+		`[line 2, column 5] Expected a data type but received IDENTIFIER ("x")`,
+		`[line 4, column 15] No prefix function for ; (";") found`,
+		`[line 5, column 19] Expected token to be ) but received ; (";")`,
+	}
+
+	errors := p.Errors()
+
+	if len(errors) != len(expectedErrors) {
+		t.Fatalf("Expected %d errors, got %d: %q", len(expectedErrors), len(errors), errors)
+	}
+
+	for i, expected := range expectedErrors {
+		if errors[i] != expected {
+			t.Errorf("errors[%d] wrong.\nExpected: %q\nGot: %q", i, expected, errors[i])
+		}
+	}
+
+	//	only the valid statements are kept
+	if len(program.Statements) != 2 {
+		t.Fatalf("Expected 2 statements, got %d: %q", len(program.Statements), program.String())
+	}
+
+	if !testVarStatements(t, program.Statements[0], "y") {
+		return
+	}
+
+	if !testReassignStatements(t, program.Statements[1], "y") {
+		return
+	}
+}
+
+func TestParserErrorsInBlocks(t *testing.T) {
+	input := `
+func(x) {
+	var int = ) ];
+	return x;
+};
+var int b = 1;
+`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParserProgram()
+
+	expected := `[line 3, column 10] Expected token to be IDENTIFIER but received = ("=")`
+
+	if len(p.Errors()) != 1 {
+		t.Fatalf("Expected 1 error, got %d: %q", len(p.Errors()), p.Errors())
+	}
+
+	if p.Errors()[0] != expected {
+		t.Fatalf("Wrong error.\nExpected: %q\nGot: %q", expected, p.Errors()[0])
+	}
+
+	//	the function with the error is left out of the program
+	if len(program.Statements) != 1 {
+		t.Fatalf("Expected 1 statement, got %d: %q", len(program.Statements), program.String())
+	}
+
+	if !testVarStatements(t, program.Statements[0], "b") {
+		return
+	}
+}
+
+func TestParserMissingSemicolonAtEOF(t *testing.T) {
+	l := lexer.New("var int a = 1")
+	p := New(l)
+	program := p.ParserProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("Expected 1 statement, got %d", len(program.Statements))
+	}
+}
+
+func TestTryStatement(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+		hasCatch bool
+		hasFinally bool
+	}{
+		{`try { x; } catch (e) { y; }`, "try {x} catch (e) {y}", true, false},
+		{`try { x; } finally { z; }`, "try {x} finally {z}", false, true},
+		{`try { x; } catch (err) { y; } finally { z; }`, "try {x} catch (err) {y} finally {z}", true, true},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParserProgram()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("Expected 1 statement, got %d", len(program.Statements))
+		}
+
+		statement, ok := program.Statements[0].(*ast.TryStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not an ast.TryStatement, got %T", program.Statements[0])
+		}
+
+		if (statement.Catch != nil) != tt.hasCatch || (statement.Finally != nil) != tt.hasFinally {
+			t.Errorf("Wrong blocks for %q", tt.input)
+		}
+
+		if statement.String() != tt.expected {
+			t.Errorf("Expected %q, got %q", tt.expected, statement.String())
+		}
+	}
+}
+
+func TestTryWithoutCatchOrFinally(t *testing.T) {
+	l := lexer.New("try { x; } var int a = 1;")
+	p := New(l)
+	p.ParserProgram()
+
+	expected := `[line 1, column 12] Expected catch or finally after the try block but received VAR ("var")`
+
+	if len(p.Errors()) != 1 || p.Errors()[0] != expected {
+		t.Fatalf("Expected error %q, got %q", expected, p.Errors())
+	}
+}
+
+func TestThrowStatement(t *testing.T) {
+	l := lexer.New(`throw "boom";`)
+	p := New(l)
+	program := p.ParserProgram()
+	checkParserErrors(t, p)
+
+	statement, ok := program.Statements[0].(*ast.ThrowStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not an ast.ThrowStatement, got %T", program.Statements[0])
+	}
+
+	if statement.Value.String() != "boom" {
+		t.Errorf("Expected thrown value boom, got %q", statement.Value.String())
+	}
+}
+
+func TestImportStatement(t *testing.T) {
+	l := lexer.New(`import "lib/utils.smp" as utils;`)
+	p := New(l)
+	program := p.ParserProgram()
+	checkParserErrors(t, p)
+
+	statement, ok := program.Statements[0].(*ast.ImportStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not an ast.ImportStatement, got %T", program.Statements[0])
+	}
+
+	if statement.Path.Value != "lib/utils.smp" {
+		t.Errorf("Expected path lib/utils.smp, got %q", statement.Path.Value)
+	}
+
+	if statement.Name.Value != "utils" {
+		t.Errorf("Expected name utils, got %q", statement.Name.Value)
+	}
+}
+
+func TestExportStatement(t *testing.T) {
+	l := lexer.New(`export const fn helper = func(x) { return x; }; export var int count = 0;`)
+	p := New(l)
+	program := p.ParserProgram()
+	checkParserErrors(t, p)
+
+	expected := []string{ "helper", "count" }
+	for i, name := range expected {
+		statement, ok := program.Statements[i].(*ast.ExportStatement)
+		if !ok {
+			t.Fatalf("program.Statements[%d] is not an ast.ExportStatement, got %T", i, program.Statements[i])
+		}
+
+		if statement.Name.Value != name {
+			t.Errorf("Expected exported name %s, got %q", name, statement.Name.Value)
+		}
+	}
+}
+
+func TestExportErrors(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`export 5;`, `[line 1, column 8] Expected var, const or struct after export but received INT ("5")`},
+		{`if (true) { export var int x = 1; }`, `[line 1, column 13] Exports are only allowed at the top level of a file`},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		p.ParserProgram()
+
+		if len(p.Errors()) != 1 || p.Errors()[0] != tt.expected {
+			t.Errorf("%s: expected error %q, got %q", tt.input, tt.expected, p.Errors())
+		}
+	}
+}
+
+func TestParsingMemberExpressions(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{"utils.helper", "utils.helper"},
+		{"utils.helper(1, 2)", "utils.helper(1, 2)"},
+		{"a.b.c", "a.b.c"},
+		{"-utils.value", "(-utils.value)"},
+		{"items[0].name", "(items[0]).name"},
+		{"config.map", "config.map"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+
+		program := p.ParserProgram()
+		checkParserErrors(t, p)
+
+		statement, ok := program.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not *ast.ExpressionStatement, got %T", program.Statements[0])
+		}
+
+		if statement.Expression.String() != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, statement.Expression.String())
+		}
+	}
+}
+
+func TestStructStatement(t *testing.T) {
+	l := lexer.New(`struct Point { int x; int y; } struct Line { Point start; Point end }`)
+	p := New(l)
+	program := p.ParserProgram()
+	checkParserErrors(t, p)
+
+	statement, ok := program.Statements[1].(*ast.StructStatement)
+	if !ok {
+		t.Fatalf("program.Statements[1] is not an ast.StructStatement, got %T", program.Statements[1])
+	}
+
+	if statement.String() != "struct Line { Point start; Point end; }" {
+		t.Errorf("Expected struct Line { Point start; Point end; }, got %q", statement.String())
+	}
+}
+
+func TestParsingStructLiterals(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{"Point{x: 1, y: 2}", "Point{x: 1, y: 2}"},
+		{"Point{}", "Point{}"},
+		{"geo.Point{x: 1 + 2}", "geo.Point{x: (1 + 2)}"},
+		{"Line{start: Point{x: 0}}.start", "Line{start: Point{x: 0}}.start"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+
+		program := p.ParserProgram()
+		checkParserErrors(t, p)
+
+		statement, ok := program.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not *ast.ExpressionStatement, got %T", program.Statements[0])
+		}
+
+		if statement.Expression.String() != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, statement.Expression.String())
+		}
+	}
+}
+
+func TestStructErrors(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{`struct Point { int x; int x; }`, `[line 1, column 27] Field x is declared more than once in Point`},
+		{`Point{x: 1, x: 2}`, `[line 1, column 13] Field x is given more than once`},
+		{`5{x: 1}`, `[line 1, column 2] Expected a struct name before "{" but received 5`},
+		{`struct Point { int x; } var Point p = 5;`, `[line 1, column 29] Expected type Point, got INT on: var Point p = 5;`},
+		{`var any r = g();`, `[line 1, column 5] Unknown type any`},
+		{`struct Line { Point start; }`, `[line 1, column 15] Unknown type Point`},
+		{`var Point p = Point{x: 1}; struct Point { int x; }`, `[line 1, column 5] Unknown type Point`},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		p.ParserProgram()
+
+		if len(p.Errors()) == 0 || p.Errors()[0] != tt.expected {
+			t.Errorf("%s: expected error %q, got %q", tt.input, tt.expected, p.Errors())
+		}
+	}
+}
+
+func TestDataTypeBuiltinCalls(t *testing.T) {
+	tests := []struct{
+		input string
+		expected string
+	}{
+		{"map(arr, f)", "map(arr, f)"},
+		{"map (arr, f)", "map(arr, f)"},
+		{"string (5)", "string(5)"},
+		{"map(arr, int)", "map(arr, int)"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+
+		program := p.ParserProgram()
+		checkParserErrors(t, p)
+
+		statement, ok := program.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not *ast.ExpressionStatement, got %T", program.Statements[0])
+		}
+
+		if _, ok := statement.Expression.(*ast.CallExpression); !ok {
+			t.Fatalf("%s: expression is not *ast.CallExpression, got %T", tt.input, statement.Expression)
+		}
+
+		if statement.Expression.String() != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, statement.Expression.String())
+		}
+	}
+}
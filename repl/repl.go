@@ -12,12 +12,15 @@ import (
 
 const PROMPT = ">> "
 
-func Start(in io.Reader, out io.Writer) {
+//	Start runs the REPL, evaluating every line with the same interpreter and environment
+func Start(in io.Reader, out io.Writer, interpreter *evaluator.Interpreter) {
 	scanner := bufio.NewScanner(in)
 	env := object.NewEnvironment()
+	//	the output of the programs goes to the same place as the output of the REPL
+	interpreter.Out = out
 
 	for {
-		fmt.Print(PROMPT)
+		fmt.Fprint(out, PROMPT)
 
 		scanned := scanner.Scan()
 
@@ -40,9 +43,19 @@ func Start(in io.Reader, out io.Writer) {
 			continue
 		}
 
-		evaluated := evaluator.Eval(program, env)
+		evaluated := interpreter.Eval(program, env)
 
-		if evaluated != nil {
+		//	exiting ends the session
+		if _, ok := evaluated.(*object.Exit); ok {
+			fmt.Fprintln(out, "Goodbye!")
+			return
+		}
+
+		//	errors are printed with the calls that led to them
+		if err, ok := evaluated.(*object.Error); ok {
+			io.WriteString(out, err.Traceback())
+			io.WriteString(out, "\n")
+		} else if evaluated != nil {
 			io.WriteString(out, evaluated.Inspect())
 			io.WriteString(out, "\n")
 		}
@@ -1,8 +1,8 @@
 package runfile
 
 import (
-	"bufio"
 	"fmt"
+	"io"
 	"language/evaluator"
 	"language/lexer"
 	"language/object"
@@ -10,49 +10,48 @@ import (
 	"os"
 )
 
-func ExecuteFile(fileName string) error {
-	//	opens the file
-	file, err := os.Open(fileName)
+//	ExecuteFile runs the file with the given interpreter and returns
+//	the status code the program exited with
+func ExecuteFile(fileName string, interpreter *evaluator.Interpreter) (int, error) {
+	//	reads the whole file, keeping the line breaks so errors report the right lines
+	inputFile, err := os.ReadFile(fileName)
 
 	if err != nil {
-		return fmt.Errorf("could not open the file: %w", err)
+		return 1, fmt.Errorf("could not open the file: %w", err)
 	}
 
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
 	env := object.NewEnvironment()
 
-	inputFile := ""
-
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		inputFile += line
-	}
-
-	l := lexer.New(inputFile)
+	l := lexer.New(string(inputFile))
 	p := parser.New(l)
 
 	program := p.ParserProgram()
 
+	//	if the program has errors, none of it is evaluated
 	if len(p.Errors()) != 0 {
-		printParserErrors(p.Errors())
-		// return nil
+		printParserErrors(interpreter.ErrOut, p.Errors())
+		return 1, fmt.Errorf("found %d parser errors", len(p.Errors()))
 	}
 
-	evaluated := evaluator.Eval(program, env)
-
-	if evaluated != nil {
-		fmt.Println(evaluated.Inspect())
+	evaluated := interpreter.EvalFile(fileName, program, env)
+
+	//	errors are printed with the calls that led to them, apart from the output of the program
+	if err, ok := evaluated.(*object.Error); ok {
+		fmt.Fprintln(interpreter.ErrOut, err.Traceback())
+		//	a program stopped by an error it did not catch failed
+		return 1, nil
+	} else if exit, ok := evaluated.(*object.Exit); ok {
+		return int(exit.Code), nil
+	} else if evaluated != nil {
+		fmt.Fprintln(interpreter.Out, evaluated.Inspect())
 	}
 
-	return nil
+	return 0, nil
 }
 
-func printParserErrors(errors []string) {
-	fmt.Println("Errors:")
+func printParserErrors(out io.Writer, errors []string) {
+	fmt.Fprintln(out, "Errors:")
 	for i, msg := range errors {
-		fmt.Printf("\t%d: %s\n", i+1, msg)
+		fmt.Fprintf(out, "\t%d: %s\n", i+1, msg)
 	}
 }
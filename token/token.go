@@ -5,6 +5,9 @@ type TokenType string
 type Token struct {
 	Type TokenType
 	Literal string
+	Line int //	line where the token starts, starting at 1
+	Column int //	column where the token starts, starting at 1
+	Keyword bool //	the token is a reserved word, so the int keyword is not mistaken for an int literal
 }
 
 const (
@@ -29,6 +32,7 @@ const (
 	MODULO = "%"
 	EXACT_DIVISION = "//"
 	POWER = "**"
+	QUESTION = "?"
 
 	//	Logical operators
 	AND = "&&"
@@ -45,6 +49,7 @@ const (
 	COMMA = ","
 	SEMICOLON = ";"
 	COLON = ":"
+	DOT = "."
 
 	L_PAREN = "("
 	R_PAREN = ")"
@@ -67,6 +72,15 @@ const (
 	WHILE = "WHILE"
 	IN = "IN"
 	FUNCTION_TYPE = "FN"
+	TIME = "TIME"
+	TRY = "TRY"
+	CATCH = "CATCH"
+	FINALLY = "FINALLY"
+	THROW = "THROW"
+	IMPORT = "IMPORT"
+	AS = "AS"
+	EXPORT = "EXPORT"
+	STRUCT = "STRUCT"
 
 	//	any token
 	ANY = "ANY"
@@ -92,6 +106,31 @@ var keywords = map[string]TokenType {
 	"while": WHILE,
 	"in": IN,
 	"fn": FUNCTION_TYPE,
+	"time": TIME,
+	"try": TRY,
+	"catch": CATCH,
+	"finally": FINALLY,
+	"throw": THROW,
+	"import": IMPORT,
+	"as": AS,
+	"export": EXPORT,
+	"struct": STRUCT,
+}
+
+//	data type keywords that builtins share their name with, like map(arr, fn)
+var dataTypes = map[string]bool {
+	"int": true,
+	"string": true,
+	"double": true,
+	"bool": true,
+	"array": true,
+	"map": true,
+	"time": true,
+}
+
+//	IsDataType checks if the identifier is a data type keyword
+func IsDataType(ident string) bool {
+	return dataTypes[ident]
 }
 
 func LookupIdent(ident string) TokenType {